@@ -23,20 +23,137 @@ import (
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// Naming strategies for CloudBucketSpec.NamingStrategy.
+const (
+	// NamingStrategyExact uses spec.bucketName as the bucket name.
+	NamingStrategyExact = "Exact"
+	// NamingStrategyHash appends a hash of the namespace, name and UID to the prefix.
+	NamingStrategyHash = "Hash"
+	// NamingStrategyRandom appends a random suffix to the prefix.
+	NamingStrategyRandom = "Random"
+)
+
+// DeletionProtectionAnnotation enables deletion protection when set to "true",
+// in addition to CloudBucketSpec.DeletionProtection.
+const DeletionProtectionAnnotation = "cloudbuckets.mygroup.example.com/deletion-protection"
+
+// PausedAnnotation stops the controller from acting on the CloudBucket when set to "true".
+// No bucket is created, updated or deleted until the annotation is removed.
+const PausedAnnotation = "cloudbuckets.mygroup.example.com/paused"
+
+// DryRunAnnotation makes the controller plan changes to the bucket without making them
+// when set to "true". The plan is reported in CloudBucketStatus.Plan and as events.
+const DryRunAnnotation = "cloudbuckets.mygroup.example.com/dry-run"
+
+// RetentionLockAnnotation confirms locking the retention policy when set to "true". Locking
+// is irreversible, so RetentionPolicySpec.Locked is only honored with this annotation.
+const RetentionLockAnnotation = "cloudbuckets.mygroup.example.com/confirm-retention-lock"
+
+// Planned actions for PlannedAction.Action.
+const (
+	PlannedActionCreate         = "Create"
+	PlannedActionUpdate         = "Update"
+	PlannedActionDeleteContents = "DeleteContents"
+	PlannedActionDelete         = "Delete"
+)
+
+// Delete policies for CloudBucketSpec.DeletePolicy.
+const (
+	// DeletePolicyDelete deletes the bucket, which fails while it contains objects.
+	DeletePolicyDelete = "Delete"
+	// DeletePolicyDeleteWithContents deletes every object and object version, then the bucket.
+	DeletePolicyDeleteWithContents = "DeleteWithContents"
+	// DeletePolicyOrphan leaves the bucket in place.
+	DeletePolicyOrphan = "Orphan"
+)
+
+// Management policies for CloudBucketSpec.ManagementPolicy.
+const (
+	// ManagementPolicyFull creates, updates and deletes the bucket.
+	ManagementPolicyFull = "Full"
+	// ManagementPolicyObserveOnly only reports the bucket's attributes in status.
+	ManagementPolicyObserveOnly = "ObserveOnly"
+	// ManagementPolicyCreateOnly creates the bucket if it is missing but never updates or deletes it.
+	ManagementPolicyCreateOnly = "CreateOnly"
+)
+
+// Adoption policies for CloudBucketSpec.AdoptionPolicy.
+const (
+	// AdoptionPolicyNever refuses to bind to a bucket that the controller did not create.
+	AdoptionPolicyNever = "Never"
+	// AdoptionPolicyAdopt binds to an existing bucket unless another owner manages it.
+	AdoptionPolicyAdopt = "Adopt"
+	// AdoptionPolicyForce binds to an existing bucket even if another owner manages it.
+	AdoptionPolicyForce = "Force"
+)
+
+//+kubebuilder:validation:XValidation:rule="!has(self.namingStrategy) || self.namingStrategy != 'Exact' || has(self.bucketName)",message="bucketName is required when namingStrategy is Exact"
+//+kubebuilder:validation:XValidation:rule="has(oldSelf.bucketName) == has(self.bucketName)",message="bucketName cannot be added or removed after creation"
+
 // CloudBucketSpec defines the desired state of CloudBucket
 type CloudBucketSpec struct {
 	// ProjectID is the GCP project ID where the bucket will be created.
 	//+kubebuilder:validation:Required
 	ProjectID string `json:"projectID"`
 
+	// BucketName is the name of the GCS bucket with the Exact naming strategy, or the
+	// prefix of the generated name with the Hash and Random strategies.
+	// If not specified, the CloudBucket name is used as the prefix.
+	// Names are at most 63 characters, or 222 when they contain dots.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:MinLength=3
+	//+kubebuilder:validation:MaxLength=222
+	//+kubebuilder:validation:Pattern=`^[a-z0-9][a-z0-9._-]*[a-z0-9]$`
+	//+kubebuilder:validation:XValidation:rule="self.contains('.') || size(self) <= 63",message="bucketName without dots must be at most 63 characters"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="bucketName is immutable"
+	BucketName string `json:"bucketName,omitempty"`
+
+	// NamingStrategy determines how the bucket name is derived.
+	// Valid values are "Exact" (use bucketName as-is), "Hash" (prefix plus a hash of the
+	// namespace, name and UID) or "Random" (prefix plus a random suffix).
+	// If not specified, defaults to "Exact" when bucketName is set and to "Hash" otherwise.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=Exact;Hash;Random
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="namingStrategy is immutable"
+	NamingStrategy string `json:"namingStrategy,omitempty"`
+
 	// DeletePolicy determines whether the bucket is deleted when the CloudBucket resource is deleted.
-	// Valid values are "Delete" (delete the bucket) or "Orphan" (leave the bucket).
+	// Valid values are "Delete" (delete the bucket, which must be empty), "DeleteWithContents"
+	// (delete every object and object version, then the bucket) or "Orphan" (leave the bucket).
 	// If not specified, defaults to "Orphan".
 	//+kubebuilder:validation:Optional
-	//+kubebuilder:validation:Enum=Delete;Orphan
+	//+kubebuilder:validation:Enum=Delete;DeleteWithContents;Orphan
 	//+kubebuilder:default=Orphan
 	DeletePolicy string `json:"deletePolicy,omitempty"`
 
+	// ManagementPolicy determines which changes the controller may make to the bucket.
+	// Valid values are "Full" (create, update and delete the bucket), "ObserveOnly" (never
+	// create, update or delete the bucket, only report its attributes and differences from
+	// the spec in status) or "CreateOnly" (create the bucket if it is missing, but never update
+	// or delete it). With ObserveOnly and CreateOnly the bucket is orphaned on deletion
+	// regardless of deletePolicy. If not specified, defaults to "Full".
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=Full;ObserveOnly;CreateOnly
+	//+kubebuilder:default=Full
+	ManagementPolicy string `json:"managementPolicy,omitempty"`
+
+	// DeletionProtection prevents the CloudBucket, and therefore its bucket, from being deleted.
+	// Deleting a protected CloudBucket is rejected at admission; if the deletion gets through
+	// anyway, the controller keeps the finalizer until protection is disabled.
+	// Protection can also be enabled with the deletion-protection annotation.
+	//+kubebuilder:validation:Optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
+	// AdoptionPolicy determines whether an existing bucket with the resolved name is brought
+	// under management. Valid values are "Never" (refuse to use a bucket the controller did not
+	// create), "Adopt" (adopt the bucket unless it carries another owner's managed-by label) or
+	// "Force" (adopt the bucket regardless of its managed-by label).
+	// Adopted buckets must live in spec.projectID. If not specified, defaults to "Never".
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=Never;Adopt;Force
+	//+kubebuilder:default=Never
+	AdoptionPolicy string `json:"adoptionPolicy,omitempty"`
+
 	// Location is the GCS region or multi-region where the bucket is stored (e.g., "us", "eu", "asia")
 	//+kubebuilder:validation:Optional
 	Location string `json:"location,omitempty"`
@@ -44,17 +161,261 @@ type CloudBucketSpec struct {
 	// Labels are additional key-value pairs to apply to the GCS bucket.
 	//+kubebuilder:validation:Optional
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// StorageClass is the default storage class of new objects. If not specified, a new
+	// bucket is created in STANDARD and the storage class of an existing bucket is left as
+	// it is. Only STANDARD can be combined with Autoclass.
+	//+kubebuilder:validation:Optional
+	StorageClass StorageClass `json:"storageClass,omitempty"`
+
+	// Autoclass moves objects between storage classes based on how they are accessed. If
+	// not specified, the Autoclass configuration of the bucket is left as it is.
+	//+kubebuilder:validation:Optional
+	Autoclass *AutoclassSpec `json:"autoclass,omitempty"`
+
+	// Versioning configures object versioning. If not specified, versioning is left as it
+	// is on the bucket.
+	//+kubebuilder:validation:Optional
+	Versioning *VersioningSpec `json:"versioning,omitempty"`
+
+	// Lifecycle configures object lifecycle management. If not specified, the lifecycle
+	// rules of the bucket are left as they are.
+	//+kubebuilder:validation:Optional
+	Lifecycle *LifecycleSpec `json:"lifecycle,omitempty"`
+
+	// RetentionPolicy sets the minimum time objects are retained. If not specified, the
+	// retention policy of the bucket is left as it is.
+	//+kubebuilder:validation:Optional
+	RetentionPolicy *RetentionPolicySpec `json:"retentionPolicy,omitempty"`
+
+	// SoftDeletePolicy sets how long deleted objects can be restored, which is billed as
+	// storage. If not specified, the soft delete policy of the bucket is left as it is, which
+	// for a new bucket is the GCS default of 7 days.
+	//+kubebuilder:validation:Optional
+	SoftDeletePolicy *SoftDeletePolicySpec `json:"softDeletePolicy,omitempty"`
+
+	// ResyncPeriod overrides the controller's resync period for this bucket (e.g., "5m", "1h").
+	// The bucket is checked for external deletion and drift at least this often.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:XValidation:rule="duration(self) >= duration('30s')",message="resyncPeriod must be at least 30s"
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+}
+
+// VersioningSpec configures object versioning of a bucket.
+type VersioningSpec struct {
+	// Enabled keeps noncurrent versions of objects that are overwritten or deleted, so they
+	// can be recovered.
+	Enabled bool `json:"enabled"`
+}
+
+// AutoclassSpec configures Autoclass.
+type AutoclassSpec struct {
+	// Enabled turns Autoclass on or off.
+	Enabled bool `json:"enabled"`
+
+	// TerminalStorageClass is the coldest storage class objects move to, NEARLINE or
+	// ARCHIVE. GCS uses NEARLINE if not specified. It requires Autoclass to be enabled.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=NEARLINE;ARCHIVE
+	TerminalStorageClass StorageClass `json:"terminalStorageClass,omitempty"`
+}
+
+// RetentionPolicySpec configures the retention policy of a bucket.
+type RetentionPolicySpec struct {
+	// Period is how long objects are retained after they are created (e.g., "720h"). Objects
+	// cannot be deleted or replaced until they are this old. It is rounded down to seconds.
+	Period metav1.Duration `json:"period"`
+
+	// Locked locks the retention policy, after which it cannot be removed and its period
+	// can only be increased. Locking is irreversible: it also requires the
+	// cloudbuckets.mygroup.example.com/confirm-retention-lock annotation, and a locked
+	// bucket cannot be deleted until all of its objects have been retained for the period.
+	//+kubebuilder:validation:Optional
+	Locked bool `json:"locked,omitempty"`
+}
+
+// SoftDeletePolicySpec configures the soft delete policy of a bucket.
+type SoftDeletePolicySpec struct {
+	// RetentionDuration is how long deleted objects are kept (e.g., "168h"). It must be 0,
+	// which disables soft delete, or between 7 and 90 days. It is rounded down to seconds.
+	RetentionDuration metav1.Duration `json:"retentionDuration"`
+}
+
+// StorageClass is a GCS storage class.
+// +kubebuilder:validation:Enum=STANDARD;NEARLINE;COLDLINE;ARCHIVE
+type StorageClass string
+
+// Storage classes.
+const (
+	StorageClassStandard StorageClass = "STANDARD"
+	StorageClassNearline StorageClass = "NEARLINE"
+	StorageClassColdline StorageClass = "COLDLINE"
+	StorageClassArchive  StorageClass = "ARCHIVE"
+)
+
+// Lifecycle actions for LifecycleAction.Type.
+const (
+	LifecycleActionDelete                         = "Delete"
+	LifecycleActionSetStorageClass                = "SetStorageClass"
+	LifecycleActionAbortIncompleteMultipartUpload = "AbortIncompleteMultipartUpload"
+)
+
+// LifecycleSpec configures object lifecycle management of a bucket.
+type LifecycleSpec struct {
+	// Rules are applied to the objects matching their condition. An empty list removes
+	// every lifecycle rule from the bucket.
+	//+kubebuilder:validation:MaxItems=100
+	Rules []LifecycleRule `json:"rules"`
+}
+
+// LifecycleRule applies an action to the objects matching a condition.
+// +kubebuilder:validation:XValidation:rule="self.action.type != 'AbortIncompleteMultipartUpload' || !(has(self.condition.createdBefore) || has(self.condition.numNewerVersions) || has(self.condition.isLive) || has(self.condition.matchesStorageClass) || has(self.condition.daysSinceNoncurrentTime))",message="AbortIncompleteMultipartUpload only supports the age, matchesPrefix and matchesSuffix conditions"
+type LifecycleRule struct {
+	// Action is taken on the objects matching the condition.
+	Action LifecycleAction `json:"action"`
+
+	// Condition selects the objects the action is taken on. An object must match every
+	// field that is set.
+	Condition LifecycleCondition `json:"condition"`
+}
+
+// LifecycleAction is the action of a lifecycle rule.
+// +kubebuilder:validation:XValidation:rule="self.type == 'SetStorageClass' ? has(self.storageClass) : !has(self.storageClass)",message="storageClass must be set for SetStorageClass, and only for SetStorageClass"
+type LifecycleAction struct {
+	// Type is the action: "Delete" deletes objects, "SetStorageClass" moves them to
+	// storageClass and "AbortIncompleteMultipartUpload" aborts multipart uploads.
+	//+kubebuilder:validation:Enum=Delete;SetStorageClass;AbortIncompleteMultipartUpload
+	Type string `json:"type"`
+
+	// StorageClass is the storage class objects are moved to by SetStorageClass.
+	//+kubebuilder:validation:Optional
+	StorageClass StorageClass `json:"storageClass,omitempty"`
+}
+
+// LifecycleCondition selects the objects a lifecycle rule applies to.
+// +kubebuilder:validation:XValidation:rule="has(self.age) || has(self.createdBefore) || has(self.numNewerVersions) || has(self.isLive) || has(self.matchesPrefix) || has(self.matchesSuffix) || has(self.matchesStorageClass) || has(self.daysSinceNoncurrentTime)",message="condition must set at least one field"
+type LifecycleCondition struct {
+	// Age matches objects at least this many days old. 0 matches every object.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	Age *int64 `json:"age,omitempty"`
+
+	// CreatedBefore matches objects created before this date, e.g. "2024-01-31".
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Format=date
+	CreatedBefore string `json:"createdBefore,omitempty"`
+
+	// NumNewerVersions matches noncurrent versions with at least this many newer versions.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	NumNewerVersions *int64 `json:"numNewerVersions,omitempty"`
+
+	// IsLive matches live objects when true and noncurrent versions when false.
+	//+kubebuilder:validation:Optional
+	IsLive *bool `json:"isLive,omitempty"`
+
+	// MatchesPrefix matches objects whose name starts with one of the prefixes.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:MaxItems=1000
+	MatchesPrefix []string `json:"matchesPrefix,omitempty"`
+
+	// MatchesSuffix matches objects whose name ends with one of the suffixes.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:MaxItems=1000
+	MatchesSuffix []string `json:"matchesSuffix,omitempty"`
+
+	// MatchesStorageClass matches objects in one of the storage classes.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:MaxItems=4
+	MatchesStorageClass []StorageClass `json:"matchesStorageClass,omitempty"`
+
+	// DaysSinceNoncurrentTime matches noncurrent versions that became noncurrent at least
+	// this many days ago.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	DaysSinceNoncurrentTime *int64 `json:"daysSinceNoncurrentTime,omitempty"`
 }
 
+// Condition types reported in CloudBucketStatus.Conditions.
+const (
+	// ConditionReady indicates that the bucket exists and is usable.
+	ConditionReady = "Ready"
+	// ConditionSynced indicates that the last reconciliation applied the spec to the bucket.
+	ConditionSynced = "Synced"
+	// ConditionDeleting indicates that the CloudBucket is being deleted.
+	ConditionDeleting = "Deleting"
+	// ConditionNameConflict indicates that the bucket name is taken by another project.
+	ConditionNameConflict = "NameConflict"
+	// ConditionOwnershipConflict indicates that the bucket is owned by another CloudBucket or cluster.
+	ConditionOwnershipConflict = "OwnershipConflict"
+	// ConditionDeletionBlocked indicates that deletion of the CloudBucket is blocked by deletion protection.
+	ConditionDeletionBlocked = "DeletionBlocked"
+	// ConditionPaused indicates that reconciliation is paused by the paused annotation.
+	ConditionPaused = "Paused"
+)
+
+// Condition reasons reported in CloudBucketStatus.Conditions. When a bucket operation
+// fails, Synced reports the operation, e.g. CreateFailed, and Ready the cause: one of
+// PermissionDenied, InvalidRequest, Conflict, NotFound, RateLimited, Unavailable or Unknown.
+const (
+	ReasonPending             = "Pending"
+	ReasonAvailable           = "Available"
+	ReasonReconcileSuccess    = "ReconcileSuccess"
+	ReasonImmutableFieldDrift = "ImmutableFieldDrift"
+	ReasonGetFailed           = "GetFailed"
+	ReasonCreateFailed        = "CreateFailed"
+	ReasonUpdateFailed        = "UpdateFailed"
+	ReasonDeleteFailed        = "DeleteFailed"
+	ReasonInvalidBucketName   = "InvalidBucketName"
+	ReasonNameTaken           = "NameTaken"
+	ReasonAdoptionRefused     = "AdoptionRefused"
+	ReasonOwnershipConflict   = "OwnershipConflict"
+	ReasonDeleting            = "Deleting"
+	ReasonOrphaning           = "Orphaning"
+	ReasonPurgingObjects      = "PurgingObjects"
+	ReasonDeletionProtected   = "DeletionProtected"
+	ReasonPausedByAnnotation  = "PausedByAnnotation"
+	ReasonBucketNotFound      = "BucketNotFound"
+	ReasonManagementPolicy    = "ManagementPolicy"
+	ReasonDryRun              = "DryRun"
+
+	// ReasonOwnershipCheckFailed means the owner labels of the bucket could not be read
+	// before deleting it, so it was neither deleted nor orphaned.
+	ReasonOwnershipCheckFailed = "OwnershipCheckFailed"
+
+	// ReasonRetentionLockUnconfirmed means the retention policy is not locked because the
+	// lock was not confirmed with RetentionLockAnnotation.
+	ReasonRetentionLockUnconfirmed = "RetentionLockUnconfirmed"
+)
+
 // CloudBucketStatus defines the observed state of CloudBucket
 type CloudBucketStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller.
+	//+kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations of the CloudBucket's state.
+	//+kubebuilder:validation:Optional
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
 	// BucketExists indicates whether the bucket exists in GCP.
 	BucketExists bool `json:"bucketExists"`
 
+	// Adopted indicates that the bucket existed before this CloudBucket and was adopted.
+	//+kubebuilder:validation:Optional
+	Adopted bool `json:"adopted,omitempty"`
+
 	// BucketName is the actual name of the bucket created in GCP.
 	//+kubebuilder:validation:Optional
 	BucketName string `json:"bucketName,omitempty"`
 
+	// NameAttempts counts how many times the bucket name was regenerated because
+	// the previous name was taken by another project.
+	//+kubebuilder:validation:Optional
+	NameAttempts int32 `json:"nameAttempts,omitempty"`
+
 	// LastOperation describes the last action performed by the controller (e.g., "Created", "Deleted", "Failed").
 	//+kubebuilder:validation:Optional
 	LastOperation string `json:"lastOperation,omitempty"`
@@ -66,10 +427,120 @@ type CloudBucketStatus struct {
 	// AppliedLabels are the labels currently applied to the GCS bucket.
 	//+kubebuilder:validation:Optional
 	AppliedLabels map[string]string `json:"appliedLabels,omitempty"`
+
+	// ObservedLabels are the labels observed on the GCS bucket, including labels
+	// not managed by the controller.
+	//+kubebuilder:validation:Optional
+	ObservedLabels map[string]string `json:"observedLabels,omitempty"`
+
+	// Location is the location observed on the GCS bucket.
+	//+kubebuilder:validation:Optional
+	Location string `json:"location,omitempty"`
+
+	// StorageClass is the default storage class observed on the GCS bucket.
+	//+kubebuilder:validation:Optional
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// Autoclass is the Autoclass configuration observed on the GCS bucket, if enabled.
+	//+kubebuilder:validation:Optional
+	Autoclass *AutoclassStatus `json:"autoclass,omitempty"`
+
+	// VersioningEnabled reports whether object versioning is enabled on the GCS bucket.
+	//+kubebuilder:validation:Optional
+	VersioningEnabled bool `json:"versioningEnabled,omitempty"`
+
+	// RetentionPolicy is the retention policy observed on the GCS bucket, if any.
+	//+kubebuilder:validation:Optional
+	RetentionPolicy *RetentionPolicyStatus `json:"retentionPolicy,omitempty"`
+
+	// SoftDeletePolicy is the soft delete policy observed on the GCS bucket, if any.
+	//+kubebuilder:validation:Optional
+	SoftDeletePolicy *SoftDeletePolicyStatus `json:"softDeletePolicy,omitempty"`
+
+	// Purge reports the progress of deleting the bucket's contents with the
+	// DeleteWithContents delete policy.
+	//+kubebuilder:validation:Optional
+	Purge *PurgeStatus `json:"purge,omitempty"`
+
+	// Plan lists the changes the controller would make to the bucket in dry-run mode.
+	//+kubebuilder:validation:Optional
+	Plan []PlannedAction `json:"plan,omitempty"`
+
+	// DriftedFields lists the fields that differed from the spec outside of the controller's
+	// control during the last reconciliation (e.g., "labels.env", "storageClass", "versioning.enabled", "location").
+	//+kubebuilder:validation:Optional
+	DriftedFields []string `json:"driftedFields,omitempty"`
+}
+
+// PlannedAction is a change to the bucket that dry-run mode held back.
+type PlannedAction struct {
+	// Action is the operation: "Create", "Update", "DeleteContents" or "Delete".
+	Action string `json:"action"`
+
+	// Field is the field an Update changes, e.g. "labels.env".
+	//+kubebuilder:validation:Optional
+	Field string `json:"field,omitempty"`
+
+	// Description describes the change, e.g. `set to "prod"`.
+	Description string `json:"description"`
+}
+
+// AutoclassStatus reports the Autoclass configuration of a bucket.
+type AutoclassStatus struct {
+	// Enabled indicates that Autoclass is enabled.
+	Enabled bool `json:"enabled"`
+
+	// TerminalStorageClass is the coldest storage class objects move to.
+	//+kubebuilder:validation:Optional
+	TerminalStorageClass string `json:"terminalStorageClass,omitempty"`
+}
+
+// RetentionPolicyStatus reports the retention policy of a bucket.
+type RetentionPolicyStatus struct {
+	// Period is how long objects are retained after they are created.
+	Period metav1.Duration `json:"period"`
+
+	// EffectiveTime is when the policy, or its last period increase, took effect.
+	//+kubebuilder:validation:Optional
+	EffectiveTime *metav1.Time `json:"effectiveTime,omitempty"`
+
+	// IsLocked indicates that the policy is locked.
+	//+kubebuilder:validation:Optional
+	IsLocked bool `json:"isLocked,omitempty"`
+}
+
+// SoftDeletePolicyStatus reports the soft delete policy of a bucket.
+type SoftDeletePolicyStatus struct {
+	// RetentionDuration is how long deleted objects are kept; 0 means soft delete is disabled.
+	RetentionDuration metav1.Duration `json:"retentionDuration"`
+
+	// EffectiveTime is when the policy took effect.
+	//+kubebuilder:validation:Optional
+	EffectiveTime *metav1.Time `json:"effectiveTime,omitempty"`
+}
+
+// PurgeStatus reports the progress of deleting the contents of a bucket.
+type PurgeStatus struct {
+	// StartTime is when the controller started deleting objects.
+	StartTime metav1.Time `json:"startTime"`
+
+	// ObjectsDeleted counts the object versions deleted so far, across reconciles.
+	ObjectsDeleted int64 `json:"objectsDeleted"`
+
+	// Completed indicates that the bucket was found empty.
+	//+kubebuilder:validation:Optional
+	Completed bool `json:"completed,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=cb,categories=storage
+//+kubebuilder:printcolumn:name="Bucket",type=string,JSONPath=`.status.bucketName`
+//+kubebuilder:printcolumn:name="Project",type=string,JSONPath=`.spec.projectID`
+//+kubebuilder:printcolumn:name="Location",type=string,JSONPath=`.spec.location`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Last Operation",type=string,JSONPath=`.status.lastOperation`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // CloudBucket is the Schema for the cloudbuckets API
 type CloudBucket struct {
@@ -80,6 +551,26 @@ type CloudBucket struct {
 	Status CloudBucketStatus `json:"status,omitempty"`
 }
 
+// IsDryRun reports whether the annotation requests dry-run mode.
+func (c *CloudBucket) IsDryRun() bool {
+	return c.Annotations[DryRunAnnotation] == "true"
+}
+
+// IsPaused reports whether reconciliation is paused by the annotation.
+func (c *CloudBucket) IsPaused() bool {
+	return c.Annotations[PausedAnnotation] == "true"
+}
+
+// IsRetentionLockConfirmed reports whether the annotation confirms locking the retention policy.
+func (c *CloudBucket) IsRetentionLockConfirmed() bool {
+	return c.Annotations[RetentionLockAnnotation] == "true"
+}
+
+// IsDeletionProtected reports whether deletion protection is enabled through the spec or the annotation.
+func (c *CloudBucket) IsDeletionProtected() bool {
+	return c.Spec.DeletionProtection || c.Annotations[DeletionProtectionAnnotation] == "true"
+}
+
 //+kubebuilder:object:root=true
 
 // CloudBucketList contains a list of CloudBucket
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var cloudbucketlog = logf.Log.WithName("cloudbucket-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *CloudBucket) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-mygroup-example-com-v1-cloudbucket,mutating=false,failurePolicy=fail,sideEffects=None,groups=mygroup.example.com,resources=cloudbuckets,verbs=create;update;delete,versions=v1,name=vcloudbucket.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &CloudBucket{}
+
+// maxRetentionPeriod is the longest retention period GCS accepts, about 100 years.
+const maxRetentionPeriod = 3155760000 * time.Second
+
+// Soft delete retention durations GCS accepts, other than 0.
+const (
+	minSoftDeleteRetention = 7 * 24 * time.Hour
+	maxSoftDeleteRetention = 90 * 24 * time.Hour
+)
+
+// ValidateCreate rejects invalid storage class, soft delete and retention settings, and
+// locking the retention policy without confirmation.
+func (r *CloudBucket) ValidateCreate() (admission.Warnings, error) {
+	return nil, r.validateSpec(nil)
+}
+
+// ValidateUpdate additionally rejects removing, unlocking or reducing a locked retention policy.
+// Updates that leave the spec unchanged are not validated, so finalizers and annotations can
+// still be changed on a CloudBucket created before a rule existed or while the webhook was off.
+func (r *CloudBucket) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	oldCloudBucket, ok := old.(*CloudBucket)
+	if !ok {
+		return nil, fmt.Errorf("expected a CloudBucket but got %T", old)
+	}
+	if equality.Semantic.DeepEqual(oldCloudBucket.Spec, r.Spec) {
+		return nil, nil
+	}
+	return nil, r.validateSpec(oldCloudBucket)
+}
+
+// validateSpec runs every spec validation against the previous version of the CloudBucket,
+// which is nil on create.
+func (r *CloudBucket) validateSpec(old *CloudBucket) error {
+	if err := r.validateStorageClass(); err != nil {
+		return err
+	}
+	if err := r.validateSoftDeletePolicy(); err != nil {
+		return err
+	}
+	return r.validateRetentionPolicy(old)
+}
+
+// validateStorageClass rejects combinations of storage class and Autoclass GCS refuses.
+func (r *CloudBucket) validateStorageClass() error {
+	autoclass := r.Spec.Autoclass
+	if autoclass == nil {
+		return nil
+	}
+	if !autoclass.Enabled && autoclass.TerminalStorageClass != "" {
+		return fmt.Errorf("spec.autoclass.terminalStorageClass requires spec.autoclass.enabled")
+	}
+	if autoclass.Enabled && r.Spec.StorageClass != "" && r.Spec.StorageClass != StorageClassStandard {
+		return fmt.Errorf("spec.storageClass %s cannot be combined with Autoclass, which manages the storage class of "+
+			"objects; remove it or set it to %s", r.Spec.StorageClass, StorageClassStandard)
+	}
+	return nil
+}
+
+// validateSoftDeletePolicy checks that the soft delete retention is one GCS accepts.
+func (r *CloudBucket) validateSoftDeletePolicy() error {
+	if r.Spec.SoftDeletePolicy == nil {
+		return nil
+	}
+	duration := r.Spec.SoftDeletePolicy.RetentionDuration.Duration
+	if duration != 0 && (duration < minSoftDeleteRetention || duration > maxSoftDeleteRetention) {
+		return fmt.Errorf("spec.softDeletePolicy.retentionDuration must be 0 or between %s and %s",
+			minSoftDeleteRetention, maxSoftDeleteRetention)
+	}
+	return nil
+}
+
+// validateRetentionPolicy checks the retention policy of the spec against the previous
+// version of the CloudBucket, which is nil on create.
+func (r *CloudBucket) validateRetentionPolicy(old *CloudBucket) error {
+	policy := r.Spec.RetentionPolicy
+	if policy != nil {
+		if period := policy.Period.Duration; period < 0 || period > maxRetentionPeriod {
+			return fmt.Errorf("spec.retentionPolicy.period must be between 0s and %s", maxRetentionPeriod)
+		}
+		if policy.Locked && policy.Period.Duration == 0 {
+			return fmt.Errorf("spec.retentionPolicy.period must be set to lock the retention policy")
+		}
+	}
+
+	var wasLocked bool
+	var lockedPeriod time.Duration
+	if old != nil {
+		if oldPolicy := old.Spec.RetentionPolicy; oldPolicy != nil && oldPolicy.Locked {
+			wasLocked = true
+			lockedPeriod = oldPolicy.Period.Duration
+		}
+		// The bucket may have been locked before it was adopted or outside of the spec
+		if observed := old.Status.RetentionPolicy; observed != nil && observed.IsLocked && observed.Period.Duration > lockedPeriod {
+			lockedPeriod = observed.Period.Duration
+		}
+	}
+
+	if policy != nil && policy.Locked && !wasLocked && !r.IsRetentionLockConfirmed() {
+		cloudbucketlog.Info("rejecting unconfirmed retention policy lock", "namespace", r.Namespace, "name", r.Name)
+		return fmt.Errorf("locking the retention policy of CloudBucket %s/%s is irreversible; set the %s annotation "+
+			"to \"true\" to confirm", r.Namespace, r.Name, RetentionLockAnnotation)
+	}
+	if wasLocked && (policy == nil || !policy.Locked) {
+		return fmt.Errorf("the retention policy of CloudBucket %s/%s is locked and cannot be removed or unlocked",
+			r.Namespace, r.Name)
+	}
+	if policy != nil && policy.Period.Duration < lockedPeriod {
+		return fmt.Errorf("the retention policy of CloudBucket %s/%s is locked; its period cannot be reduced below %s",
+			r.Namespace, r.Name, lockedPeriod)
+	}
+	return nil
+}
+
+// ValidateDelete rejects deleting a CloudBucket with deletion protection enabled.
+func (r *CloudBucket) ValidateDelete() (admission.Warnings, error) {
+	if r.IsDeletionProtected() {
+		cloudbucketlog.Info("rejecting deletion of protected CloudBucket", "namespace", r.Namespace, "name", r.Name)
+		return nil, fmt.Errorf("CloudBucket %s/%s has deletion protection enabled; set spec.deletionProtection to false "+
+			"and remove the %s annotation before deleting it", r.Namespace, r.Name, DeletionProtectionAnnotation)
+	}
+	return nil, nil
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CloudBucket Webhook", func() {
+
+	newCloudBucket := func(name string) *CloudBucket {
+		return &CloudBucket{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       CloudBucketSpec{ProjectID: "test-project"},
+		}
+	}
+
+	Context("When deleting a CloudBucket", func() {
+		It("should allow deleting an unprotected CloudBucket", func() {
+			_, err := newCloudBucket("unprotected").ValidateDelete()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject deleting a CloudBucket protected by the spec", func() {
+			cloudBucket := newCloudBucket("protected")
+			cloudBucket.Spec.DeletionProtection = true
+			_, err := cloudBucket.ValidateDelete()
+			Expect(err).To(MatchError(ContainSubstring("deletion protection enabled")))
+		})
+
+		It("should reject deleting a CloudBucket protected by the annotation", func() {
+			cloudBucket := newCloudBucket("annotated")
+			cloudBucket.Annotations = map[string]string{DeletionProtectionAnnotation: "true"}
+			_, err := cloudBucket.ValidateDelete()
+			Expect(err).To(MatchError(ContainSubstring("deletion protection enabled")))
+		})
+
+		It("should reject the deletion through the API server until protection is disabled", func() {
+			cloudBucket := newCloudBucket("api-protected")
+			cloudBucket.Spec.DeletionProtection = true
+			Expect(k8sClient.Create(ctx, cloudBucket)).To(Succeed())
+
+			Expect(k8sClient.Delete(ctx, cloudBucket)).To(MatchError(ContainSubstring("deletion protection enabled")))
+
+			cloudBucket.Spec.DeletionProtection = false
+			Expect(k8sClient.Update(ctx, cloudBucket)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, cloudBucket)).To(Succeed())
+		})
+	})
+
+	Context("When updating a CloudBucket", func() {
+		It("should only validate updates that change the spec", func() {
+			// Created while the webhook was off, before the soft delete rule existed
+			old := newCloudBucket("unvalidated")
+			old.Finalizers = []string{"cloudbuckets.mygroup.example.com/finalizer"}
+			old.Spec.SoftDeletePolicy = &SoftDeletePolicySpec{RetentionDuration: metav1.Duration{Duration: time.Hour}}
+
+			cloudBucket := old.DeepCopy()
+			cloudBucket.Finalizers = nil
+			cloudBucket.Annotations = map[string]string{PausedAnnotation: "true"}
+			_, err := cloudBucket.ValidateUpdate(old)
+			Expect(err).NotTo(HaveOccurred())
+
+			cloudBucket.Spec.DeletionProtection = true
+			_, err = cloudBucket.ValidateUpdate(old)
+			Expect(err).To(MatchError(ContainSubstring("spec.softDeletePolicy.retentionDuration")))
+		})
+	})
+
+	Context("When validating the bucket name", func() {
+		It("should accept long dotted names and keep the name fixed through the API server", func() {
+			cloudBucket := newCloudBucket("dotted-name")
+			cloudBucket.Spec.BucketName = strings.Repeat("a", 63) + "." + strings.Repeat("b", 63) + ".example.com"
+			Expect(k8sClient.Create(ctx, cloudBucket)).To(Succeed())
+
+			undotted := newCloudBucket("long-name")
+			undotted.Spec.BucketName = strings.Repeat("a", 64)
+			Expect(k8sClient.Create(ctx, undotted)).To(MatchError(ContainSubstring("at most 63 characters")))
+
+			unnamed := newCloudBucket("unnamed")
+			Expect(k8sClient.Create(ctx, unnamed)).To(Succeed())
+			unnamed.Spec.BucketName = "acme-unnamed"
+			Expect(k8sClient.Update(ctx, unnamed)).To(MatchError(ContainSubstring("cannot be added or removed")))
+		})
+	})
+
+	Context("When validating the retention policy", func() {
+		withRetention := func(name string, period time.Duration, locked bool) *CloudBucket {
+			cloudBucket := newCloudBucket(name)
+			cloudBucket.Spec.RetentionPolicy = &RetentionPolicySpec{Period: metav1.Duration{Duration: period}, Locked: locked}
+			return cloudBucket
+		}
+
+		It("should only lock the retention policy with the confirmation annotation", func() {
+			cloudBucket := withRetention("lock", 24*time.Hour, true)
+			_, err := cloudBucket.ValidateCreate()
+			Expect(err).To(MatchError(ContainSubstring(RetentionLockAnnotation)))
+
+			cloudBucket.Annotations = map[string]string{RetentionLockAnnotation: "true"}
+			_, err = cloudBucket.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = withRetention("unlocked", 24*time.Hour, false).ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a negative or missing period", func() {
+			_, err := withRetention("negative", -time.Hour, false).ValidateCreate()
+			Expect(err).To(MatchError(ContainSubstring("must be between")))
+
+			cloudBucket := withRetention("zero", 0, true)
+			cloudBucket.Annotations = map[string]string{RetentionLockAnnotation: "true"}
+			_, err = cloudBucket.ValidateCreate()
+			Expect(err).To(MatchError(ContainSubstring("must be set to lock")))
+		})
+
+		It("should only allow increasing the period of a locked policy", func() {
+			old := withRetention("locked", 48*time.Hour, true)
+
+			_, err := withRetention("locked", 72*time.Hour, true).ValidateUpdate(old)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = withRetention("locked", 24*time.Hour, true).ValidateUpdate(old)
+			Expect(err).To(MatchError(ContainSubstring("cannot be reduced")))
+
+			_, err = withRetention("locked", 48*time.Hour, false).ValidateUpdate(old)
+			Expect(err).To(MatchError(ContainSubstring("cannot be removed or unlocked")))
+
+			_, err = newCloudBucket("locked").ValidateUpdate(old)
+			Expect(err).To(MatchError(ContainSubstring("cannot be removed or unlocked")))
+		})
+
+		It("should not reduce the period below a lock observed on the bucket", func() {
+			old := withRetention("observed", 48*time.Hour, false)
+			old.Status.RetentionPolicy = &RetentionPolicyStatus{Period: metav1.Duration{Duration: 48 * time.Hour}, IsLocked: true}
+
+			_, err := withRetention("observed", 24*time.Hour, false).ValidateUpdate(old)
+			Expect(err).To(MatchError(ContainSubstring("cannot be reduced below 48h")))
+
+			_, err = withRetention("observed", 48*time.Hour, false).ValidateUpdate(old)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When validating the soft delete policy", func() {
+		It("should only accept 0 or a retention duration GCS supports", func() {
+			for _, duration := range []time.Duration{0, 7 * 24 * time.Hour, 90 * 24 * time.Hour} {
+				cloudBucket := newCloudBucket("soft-delete")
+				cloudBucket.Spec.SoftDeletePolicy = &SoftDeletePolicySpec{RetentionDuration: metav1.Duration{Duration: duration}}
+				_, err := cloudBucket.ValidateCreate()
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			for _, duration := range []time.Duration{24 * time.Hour, 91 * 24 * time.Hour} {
+				cloudBucket := newCloudBucket("soft-delete")
+				cloudBucket.Spec.SoftDeletePolicy = &SoftDeletePolicySpec{RetentionDuration: metav1.Duration{Duration: duration}}
+				_, err := cloudBucket.ValidateUpdate(newCloudBucket("soft-delete"))
+				Expect(err).To(MatchError(ContainSubstring("must be 0 or between")))
+			}
+		})
+	})
+
+	Context("When validating the storage class", func() {
+		It("should only combine Autoclass with the STANDARD storage class", func() {
+			cloudBucket := newCloudBucket("autoclass")
+			cloudBucket.Spec.Autoclass = &AutoclassSpec{Enabled: true, TerminalStorageClass: StorageClassArchive}
+			_, err := cloudBucket.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+
+			cloudBucket.Spec.StorageClass = StorageClassStandard
+			_, err = cloudBucket.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+
+			cloudBucket.Spec.StorageClass = StorageClassNearline
+			_, err = cloudBucket.ValidateUpdate(newCloudBucket("autoclass"))
+			Expect(err).To(MatchError(ContainSubstring("cannot be combined with Autoclass")))
+
+			cloudBucket.Spec.Autoclass.Enabled = false
+			_, err = cloudBucket.ValidateCreate()
+			Expect(err).To(MatchError(ContainSubstring("requires spec.autoclass.enabled")))
+		})
+	})
+})
@@ -21,9 +21,40 @@ limitations under the License.
 package v1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoclassSpec) DeepCopyInto(out *AutoclassSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoclassSpec.
+func (in *AutoclassSpec) DeepCopy() *AutoclassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoclassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoclassStatus) DeepCopyInto(out *AutoclassStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoclassStatus.
+func (in *AutoclassStatus) DeepCopy() *AutoclassStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoclassStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudBucket) DeepCopyInto(out *CloudBucket) {
 	*out = *in
@@ -93,6 +124,36 @@ func (in *CloudBucketSpec) DeepCopyInto(out *CloudBucketSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Autoclass != nil {
+		in, out := &in.Autoclass, &out.Autoclass
+		*out = new(AutoclassSpec)
+		**out = **in
+	}
+	if in.Versioning != nil {
+		in, out := &in.Versioning, &out.Versioning
+		*out = new(VersioningSpec)
+		**out = **in
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(LifecycleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(RetentionPolicySpec)
+		**out = **in
+	}
+	if in.SoftDeletePolicy != nil {
+		in, out := &in.SoftDeletePolicy, &out.SoftDeletePolicy
+		*out = new(SoftDeletePolicySpec)
+		**out = **in
+	}
+	if in.ResyncPeriod != nil {
+		in, out := &in.ResyncPeriod, &out.ResyncPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudBucketSpec.
@@ -108,6 +169,13 @@ func (in *CloudBucketSpec) DeepCopy() *CloudBucketSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudBucketStatus) DeepCopyInto(out *CloudBucketStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.AppliedLabels != nil {
 		in, out := &in.AppliedLabels, &out.AppliedLabels
 		*out = make(map[string]string, len(*in))
@@ -115,6 +183,43 @@ func (in *CloudBucketStatus) DeepCopyInto(out *CloudBucketStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.ObservedLabels != nil {
+		in, out := &in.ObservedLabels, &out.ObservedLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Autoclass != nil {
+		in, out := &in.Autoclass, &out.Autoclass
+		*out = new(AutoclassStatus)
+		**out = **in
+	}
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(RetentionPolicyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SoftDeletePolicy != nil {
+		in, out := &in.SoftDeletePolicy, &out.SoftDeletePolicy
+		*out = new(SoftDeletePolicyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Purge != nil {
+		in, out := &in.Purge, &out.Purge
+		*out = new(PurgeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = make([]PlannedAction, len(*in))
+		copy(*out, *in)
+	}
+	if in.DriftedFields != nil {
+		in, out := &in.DriftedFields, &out.DriftedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudBucketStatus.
@@ -126,3 +231,225 @@ func (in *CloudBucketStatus) DeepCopy() *CloudBucketStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleAction) DeepCopyInto(out *LifecycleAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleAction.
+func (in *LifecycleAction) DeepCopy() *LifecycleAction {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleCondition) DeepCopyInto(out *LifecycleCondition) {
+	*out = *in
+	if in.Age != nil {
+		in, out := &in.Age, &out.Age
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NumNewerVersions != nil {
+		in, out := &in.NumNewerVersions, &out.NumNewerVersions
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IsLive != nil {
+		in, out := &in.IsLive, &out.IsLive
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MatchesPrefix != nil {
+		in, out := &in.MatchesPrefix, &out.MatchesPrefix
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatchesSuffix != nil {
+		in, out := &in.MatchesSuffix, &out.MatchesSuffix
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatchesStorageClass != nil {
+		in, out := &in.MatchesStorageClass, &out.MatchesStorageClass
+		*out = make([]StorageClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.DaysSinceNoncurrentTime != nil {
+		in, out := &in.DaysSinceNoncurrentTime, &out.DaysSinceNoncurrentTime
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleCondition.
+func (in *LifecycleCondition) DeepCopy() *LifecycleCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleRule) DeepCopyInto(out *LifecycleRule) {
+	*out = *in
+	out.Action = in.Action
+	in.Condition.DeepCopyInto(&out.Condition)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleRule.
+func (in *LifecycleRule) DeepCopy() *LifecycleRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleSpec) DeepCopyInto(out *LifecycleSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]LifecycleRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleSpec.
+func (in *LifecycleSpec) DeepCopy() *LifecycleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedAction) DeepCopyInto(out *PlannedAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedAction.
+func (in *PlannedAction) DeepCopy() *PlannedAction {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PurgeStatus) DeepCopyInto(out *PurgeStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PurgeStatus.
+func (in *PurgeStatus) DeepCopy() *PurgeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PurgeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetentionPolicySpec) DeepCopyInto(out *RetentionPolicySpec) {
+	*out = *in
+	out.Period = in.Period
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionPolicySpec.
+func (in *RetentionPolicySpec) DeepCopy() *RetentionPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RetentionPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetentionPolicyStatus) DeepCopyInto(out *RetentionPolicyStatus) {
+	*out = *in
+	out.Period = in.Period
+	if in.EffectiveTime != nil {
+		in, out := &in.EffectiveTime, &out.EffectiveTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionPolicyStatus.
+func (in *RetentionPolicyStatus) DeepCopy() *RetentionPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RetentionPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SoftDeletePolicySpec) DeepCopyInto(out *SoftDeletePolicySpec) {
+	*out = *in
+	out.RetentionDuration = in.RetentionDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SoftDeletePolicySpec.
+func (in *SoftDeletePolicySpec) DeepCopy() *SoftDeletePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SoftDeletePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SoftDeletePolicyStatus) DeepCopyInto(out *SoftDeletePolicyStatus) {
+	*out = *in
+	out.RetentionDuration = in.RetentionDuration
+	if in.EffectiveTime != nil {
+		in, out := &in.EffectiveTime, &out.EffectiveTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SoftDeletePolicyStatus.
+func (in *SoftDeletePolicyStatus) DeepCopy() *SoftDeletePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SoftDeletePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersioningSpec) DeepCopyInto(out *VersioningSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersioningSpec.
+func (in *VersioningSpec) DeepCopy() *VersioningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VersioningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
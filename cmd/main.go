@@ -20,8 +20,8 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"time"
 
-	"cloud.google.com/go/storage"
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -37,6 +37,7 @@ import (
 
 	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
 	"github.com/andreistefanciprian/cloud-storage-controller/internal/controller"
+	"github.com/andreistefanciprian/cloud-storage-controller/internal/provider"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -58,6 +59,10 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var gcsOpts provider.GCSClientOptions
+	var resyncPeriod time.Duration
+	var clusterID string
+	var dryRun bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -67,6 +72,23 @@ func main() {
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&gcsOpts.Endpoint, "gcs-endpoint", os.Getenv("GCS_ENDPOINT"),
+		"Custom GCS JSON API endpoint, e.g. http://fake-gcs-server:4443/storage/v1/. "+
+			"Defaults to $GCS_ENDPOINT, or Google Cloud Storage if unset.")
+	flag.BoolVar(&gcsOpts.NoAuth, "gcs-no-auth", os.Getenv("GCS_NO_AUTH") == "true",
+		"If set, the GCS client does not authenticate. Intended for emulators. Defaults to $GCS_NO_AUTH.")
+	flag.BoolVar(&gcsOpts.Insecure, "gcs-insecure", os.Getenv("GCS_INSECURE") == "true",
+		"If set, TLS verification is skipped for the GCS endpoint and no credentials are sent. "+
+			"Intended for emulators. Defaults to $GCS_INSECURE.")
+	flag.DurationVar(&resyncPeriod, "resync-period", 10*time.Minute,
+		"How often each CloudBucket is re-checked for external deletion and drift. "+
+			"Can be overridden per object with spec.resyncPeriod. Set to 0 to disable.")
+	flag.StringVar(&clusterID, "cluster-id", os.Getenv("CLUSTER_ID"),
+		"Identifies this cluster in the owner labels stamped on buckets, so controllers in "+
+			"different clusters do not manage the same bucket. Defaults to $CLUSTER_ID.")
+	flag.BoolVar(&dryRun, "dry-run", os.Getenv("DRY_RUN") == "true",
+		"If set, bucket creates, updates and deletes are only planned and reported in each "+
+			"CloudBucket's status.plan and events. Defaults to $DRY_RUN.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -93,7 +115,11 @@ func main() {
 
 	// Initialize GCS client
 	ctx := ctrl.SetupSignalHandler()
-	gcsClient, err := storage.NewClient(ctx)
+	if gcsOpts.Endpoint != "" {
+		setupLog.Info("using custom GCS endpoint", "endpoint", gcsOpts.Endpoint,
+			"noAuth", gcsOpts.NoAuth, "insecure", gcsOpts.Insecure)
+	}
+	gcsClient, err := provider.NewGCSClient(ctx, gcsOpts)
 	if err != nil {
 		setupLog.Error(err, "unable to create GCS client")
 		os.Exit(1)
@@ -118,13 +144,22 @@ func main() {
 	}
 
 	if err = (&controller.CloudBucketReconciler{
-		Client:    mgr.GetClient(),
-		Scheme:    mgr.GetScheme(),
-		GCSClient: gcsClient,
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Provider:     provider.NewGCSProvider(gcsClient),
+		ResyncPeriod: resyncPeriod,
+		ClusterID:    clusterID,
+		DryRun:       dryRun,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "CloudBucket")
 		os.Exit(1)
 	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&mygroupv1.CloudBucket{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CloudBucket")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+	"github.com/andreistefanciprian/cloud-storage-controller/internal/provider"
+)
+
+// adoptionPolicy returns the adoption policy of the CloudBucket, defaulting to Never
+func adoptionPolicy(cloudBucket *mygroupv1.CloudBucket) string {
+	if cloudBucket.Spec.AdoptionPolicy == "" {
+		return mygroupv1.AdoptionPolicyNever
+	}
+	return cloudBucket.Spec.AdoptionPolicy
+}
+
+// isManagedByUs reports whether the bucket carries the controller's managed-by label
+func isManagedByUs(bucket *provider.Bucket) bool {
+	return bucket.Labels[managedByLabel] == managedByValue
+}
+
+// isCreatedFor reports whether the bucket carries the managed-by label together with the
+// owner UID of the CloudBucket, i.e. it was created for this CloudBucket
+func isCreatedFor(cloudBucket *mygroupv1.CloudBucket, bucket *provider.Bucket) bool {
+	return isManagedByUs(bucket) && bucket.Labels[ownerUIDLabel] == uidHash(cloudBucket)
+}
+
+// checkAdoption decides whether a bucket found before the CloudBucket bound to it may be
+// managed. It returns a refusal when the bucket must be left alone, and an error when the
+// check itself failed.
+func (r *CloudBucketReconciler) checkAdoption(ctx context.Context, cloudBucket *mygroupv1.CloudBucket, bucket *provider.Bucket) (refusal error, err error) {
+	// Get succeeds for any bucket we can read, so confirm it belongs to spec.projectID
+	inOtherProject, err := r.isNameTaken(ctx, cloudBucket)
+	if err != nil {
+		return nil, err
+	}
+	if inOtherProject {
+		return fmt.Errorf("bucket %s does not belong to project %s", bucket.Name, cloudBucket.Spec.ProjectID), nil
+	}
+
+	// A bucket labelled with our UID was created by us, e.g. before a status update was lost
+	if isCreatedFor(cloudBucket, bucket) {
+		return nil, nil
+	}
+
+	policy := adoptionPolicy(cloudBucket)
+	if policy == mygroupv1.AdoptionPolicyNever {
+		return fmt.Errorf("bucket %s already exists and adoptionPolicy is Never", bucket.Name), nil
+	}
+
+	if owner, ok := bucket.Labels[managedByLabel]; ok && policy != mygroupv1.AdoptionPolicyForce {
+		return fmt.Errorf("bucket %s is managed by %s; set adoptionPolicy to Force to take it over", bucket.Name, owner), nil
+	}
+	return nil, nil
+}
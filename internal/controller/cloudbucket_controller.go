@@ -19,30 +19,47 @@ package controller
 import (
 	"context"
 	"fmt"
-	"math/rand"
-	"reflect"
-	"strings"
 	"time"
 
-	"cloud.google.com/go/storage"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+	"github.com/andreistefanciprian/cloud-storage-controller/internal/provider"
 )
 
+// bucketFinalizer keeps a CloudBucket until its bucket has been deleted or orphaned
+const bucketFinalizer = "cloudbuckets.mygroup.example.com/finalizer"
+
+// resyncJitterFactor spreads periodic resyncs so buckets are not all checked at once
+const resyncJitterFactor = 0.1
+
 // CloudBucketReconciler reconciles a CloudBucket object
 type CloudBucketReconciler struct {
 	client.Client
 	Scheme        *runtime.Scheme
-	GCSClient     *storage.Client
+	Provider      provider.BucketProvider
 	EventRecorder record.EventRecorder
+	// ResyncPeriod is how often a reconciled CloudBucket is checked again for
+	// external deletion and drift. Zero disables periodic resyncs.
+	ResyncPeriod time.Duration
+	// ClusterID identifies this cluster in the owner labels stamped on buckets, so
+	// controllers in different clusters do not fight over the same bucket.
+	ClusterID string
+	// DryRun makes the controller plan creates, updates and deletes of buckets without
+	// making them, for every CloudBucket.
+	DryRun bool
 }
 
 //+kubebuilder:rbac:groups=mygroup.example.com,resources=cloudbuckets,verbs=get;list;watch;create;update;patch;delete
@@ -64,58 +81,176 @@ func (r *CloudBucketReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Failed to get CloudBucket")
-		ErrorsTotal.Inc()
+		countError(operationFetch, err)
 		r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "FetchFailed", fmt.Sprintf("Failed to get CloudBucket: %v", err))
 		return ctrl.Result{}, err
 	}
 
-	// Initialize status if empty
-	if cloudBucket.Status.BucketExists == false && cloudBucket.Status.LastOperation == "" {
-		cloudBucket.Status = mygroupv1.CloudBucketStatus{
-			BucketExists:  false,
-			LastOperation: "Pending",
+	// Status is written once per reconcile, as a merge patch against the object as it was read
+	original := cloudBucket.DeepCopy()
+	result, err := r.reconcile(ctx, cloudBucket)
+	if patchErr := r.patchStatus(ctx, original, cloudBucket); errors.IsConflict(patchErr) {
+		// The CloudBucket changed while reconciling; reconcile again against the latest object
+		log.Info("CloudBucket changed during reconcile, requeueing", "resourceVersion", cloudBucket.ResourceVersion)
+		if err == nil {
+			return ctrl.Result{Requeue: true}, nil
+		}
+	} else if patchErr != nil {
+		log.Error(patchErr, "Failed to update CloudBucket status")
+		countError(operationStatus, patchErr)
+		r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "StatusUpdateFailed", fmt.Sprintf("Failed to update status: %v", patchErr))
+		if err == nil {
+			return ctrl.Result{}, patchErr
 		}
 	}
+	return result, err
+}
+
+// reconcile moves the bucket towards the spec of the CloudBucket and records the outcome
+// in its status, which the caller persists
+func (r *CloudBucketReconciler) reconcile(ctx context.Context, cloudBucket *mygroupv1.CloudBucket) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	var err error
+
+	// Initialize status if empty
+	if !cloudBucket.Status.BucketExists && cloudBucket.Status.LastOperation == "" {
+		// Keep fields such as a regenerated bucket name that were persisted before the first outcome
+		cloudBucket.Status.LastOperation = "Pending"
+		setCondition(cloudBucket, mygroupv1.ConditionReady, metav1.ConditionUnknown, mygroupv1.ReasonPending, "Waiting for the bucket to be reconciled")
+	}
 
-	// Define finalizer
-	const bucketFinalizer = "cloudbuckets.mygroup.example.com/finalizer"
+	// While paused, leave the bucket and the finalizer alone, including on deletion
+	if cloudBucket.IsPaused() {
+		if !meta.IsStatusConditionTrue(cloudBucket.Status.Conditions, mygroupv1.ConditionPaused) {
+			log.Info("Reconciliation paused", "bucketName", cloudBucket.Status.BucketName)
+			r.EventRecorder.Event(cloudBucket, corev1.EventTypeNormal, "Paused",
+				fmt.Sprintf("Reconciliation paused by the %s annotation", mygroupv1.PausedAnnotation))
+		}
+		markObserved(cloudBucket)
+		setCondition(cloudBucket, mygroupv1.ConditionPaused, metav1.ConditionTrue, mygroupv1.ReasonPausedByAnnotation,
+			fmt.Sprintf("Remove the %s annotation to resume reconciliation", mygroupv1.PausedAnnotation))
+		// Removing the annotation updates the object, which triggers the next reconcile
+		return ctrl.Result{}, nil
+	}
+	if meta.FindStatusCondition(cloudBucket.Status.Conditions, mygroupv1.ConditionPaused) != nil {
+		// The rest of this reconcile compares every field against the bucket, so
+		// anything that changed while paused is picked up now
+		log.Info("Reconciliation resumed", "bucketName", cloudBucket.Status.BucketName)
+		meta.RemoveStatusCondition(&cloudBucket.Status.Conditions, mygroupv1.ConditionPaused)
+		r.EventRecorder.Event(cloudBucket, corev1.EventTypeNormal, "Resumed", "Reconciliation resumed, checking the bucket for drift")
+	}
+	dryRun := r.isDryRun(cloudBucket)
+	if !dryRun {
+		cloudBucket.Status.Plan = nil
+	}
 
 	// Check if the CloudBucket is being deleted
 	if cloudBucket.GetDeletionTimestamp() != nil {
 		if controllerutil.ContainsFinalizer(cloudBucket, bucketFinalizer) {
-			if cloudBucket.Spec.DeletePolicy == "Delete" && cloudBucket.Status.BucketName != "" {
-				log.Info("Deleting bucket due to CloudBucket deletion", "bucketName", cloudBucket.Status.BucketName)
-				err = r.deleteBucket(ctx, cloudBucket.Status.BucketName)
+			// Deletion protection keeps the finalizer, and the bucket, until it is disabled
+			if cloudBucket.IsDeletionProtected() {
+				log.Info("Deletion blocked by deletion protection", "bucketName", cloudBucket.Status.BucketName)
+				message := "Deletion protection is enabled; disable spec.deletionProtection and remove the " +
+					mygroupv1.DeletionProtectionAnnotation + " annotation to delete the CloudBucket"
+				markObserved(cloudBucket)
+				setCondition(cloudBucket, mygroupv1.ConditionDeletionBlocked, metav1.ConditionTrue, mygroupv1.ReasonDeletionProtected, message)
+				r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "DeletionBlocked", message)
+				// Disabling protection updates the object, which triggers the next reconcile
+				return ctrl.Result{}, nil
+			}
+			meta.RemoveStatusCondition(&cloudBucket.Status.Conditions, mygroupv1.ConditionDeletionBlocked)
+
+			setCondition(cloudBucket, mygroupv1.ConditionReady, metav1.ConditionFalse, mygroupv1.ReasonDeleting, "CloudBucket is being deleted")
+			// Only delete a bucket this CloudBucket created or adopted, never one that
+			// belongs to another project, whose adoption was refused or that is only observed
+			deleteBucket := deletesBucket(cloudBucket) && mayDelete(cloudBucket) && cloudBucket.Status.BucketName != "" && isBound(cloudBucket)
+			var conflict error
+			if deleteBucket {
+				// Check the owner labels right before deleting, in case the bucket changed hands
+				conflict, err = r.checkBucketOwnership(ctx, cloudBucket)
 				if err != nil {
-					log.Error(err, "Failed to delete bucket")
-					cloudBucket.Status.LastOperation = "Failed"
-					cloudBucket.Status.ErrorMessage = err.Error()
-					ErrorsTotal.Inc()
-					r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "BucketFailed", fmt.Sprintf("Failed to delete bucket: %v", err))
-					if updateErr := r.Status().Update(ctx, cloudBucket); updateErr != nil {
-						log.Error(updateErr, "Failed to update CloudBucket status")
-						ErrorsTotal.Inc()
+					// Neither delete nor orphan a bucket whose owner is unknown
+					setCondition(cloudBucket, mygroupv1.ConditionDeleting, metav1.ConditionTrue, mygroupv1.ReasonOwnershipCheckFailed,
+						fmt.Sprintf("Checking the owner of bucket %s before deleting it failed: %v", cloudBucket.Status.BucketName, err))
+					return r.handleProviderError(ctx, cloudBucket, operationOwnership, mygroupv1.ReasonOwnershipCheckFailed,
+						"check bucket ownership before deleting", err)
+				}
+				if conflict != nil {
+					deleteBucket = false
+				}
+			}
+			if deleteBucket && dryRun {
+				// Keep the finalizer, and the bucket, until dry-run mode is disabled
+				log.Info("Dry run, not deleting bucket", "bucketName", cloudBucket.Status.BucketName)
+				r.recordPlan(cloudBucket, planDelete(cloudBucket))
+				markObserved(cloudBucket)
+				setCondition(cloudBucket, mygroupv1.ConditionDeleting, metav1.ConditionTrue, mygroupv1.ReasonDryRun,
+					"Dry run: "+planSummary(cloudBucket.Status.Plan))
+				return ctrl.Result{RequeueAfter: r.resyncPeriod(cloudBucket)}, nil
+			}
+			if deleteBucket {
+				log.Info("Deleting bucket due to CloudBucket deletion", "bucketName", cloudBucket.Status.BucketName)
+				setCondition(cloudBucket, mygroupv1.ConditionDeleting, metav1.ConditionTrue, mygroupv1.ReasonDeleting,
+					fmt.Sprintf("Deleting bucket %s", cloudBucket.Status.BucketName))
+				if cloudBucket.Spec.DeletePolicy == mygroupv1.DeletePolicyDeleteWithContents {
+					setCondition(cloudBucket, mygroupv1.ConditionDeleting, metav1.ConditionTrue, mygroupv1.ReasonPurgingObjects,
+						fmt.Sprintf("Deleting the contents of bucket %s", cloudBucket.Status.BucketName))
+					var purged bool
+					purged, err = r.purgeBucket(ctx, cloudBucket)
+					if err == nil && !purged {
+						log.Info("Purging bucket contents", "bucketName", cloudBucket.Status.BucketName, "objectsDeleted", cloudBucket.Status.Purge.ObjectsDeleted)
+						setCondition(cloudBucket, mygroupv1.ConditionDeleting, metav1.ConditionTrue, mygroupv1.ReasonPurgingObjects,
+							fmt.Sprintf("Deleted %d objects from bucket %s so far", cloudBucket.Status.Purge.ObjectsDeleted, cloudBucket.Status.BucketName))
+						return ctrl.Result{RequeueAfter: purgeRequeueDelay}, nil
 					}
-					return ctrl.Result{RequeueAfter: 30 * time.Second}, err
+				}
+				if err == nil {
+					err = r.deleteBucket(ctx, cloudBucket.Status.BucketName)
+				}
+				if err != nil {
+					return r.handleProviderError(ctx, cloudBucket, operationDelete, mygroupv1.ReasonDeleteFailed, "delete bucket", err)
 				}
 				cloudBucket.Status.BucketExists = false
 				cloudBucket.Status.LastOperation = "Deleted"
 				cloudBucket.Status.ErrorMessage = ""
 				BucketsDeleted.Inc()
 				r.EventRecorder.Event(cloudBucket, corev1.EventTypeNormal, "BucketDeleted", fmt.Sprintf("Bucket %s deleted successfully", cloudBucket.Status.BucketName))
+			} else if conflict != nil {
+				log.Info("Orphaning bucket owned by someone else", "bucketName", cloudBucket.Status.BucketName, "reason", conflict.Error())
+				setCondition(cloudBucket, mygroupv1.ConditionOwnershipConflict, metav1.ConditionTrue, mygroupv1.ReasonOwnershipConflict, conflict.Error())
+				setCondition(cloudBucket, mygroupv1.ConditionDeleting, metav1.ConditionTrue, mygroupv1.ReasonOrphaning,
+					fmt.Sprintf("Orphaning bucket %s: %v", cloudBucket.Status.BucketName, conflict))
+				cloudBucket.Status.LastOperation = "Orphaned"
+				cloudBucket.Status.ErrorMessage = ""
+				BucketsOrphaned.Inc()
+				r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "OwnershipConflict",
+					fmt.Sprintf("Bucket %s orphaned instead of deleted: %v", cloudBucket.Status.BucketName, conflict))
 			} else {
-				log.Info("Orphaning bucket due to deletePolicy", "bucketName", cloudBucket.Status.BucketName)
+				var cause string
+				switch {
+				case !deletesBucket(cloudBucket):
+					cause = "delete policy"
+				case !mayDelete(cloudBucket):
+					cause = "management policy " + managementPolicy(cloudBucket)
+				default:
+					// e.g. a refused adoption, a name conflict or a bucket that was never created
+					cause = "the bucket not being bound to this CloudBucket"
+				}
+				log.Info("Orphaning bucket due to "+cause, "bucketName", cloudBucket.Status.BucketName)
+				setCondition(cloudBucket, mygroupv1.ConditionDeleting, metav1.ConditionTrue, mygroupv1.ReasonOrphaning,
+					fmt.Sprintf("Orphaning bucket %s", cloudBucket.Status.BucketName))
 				cloudBucket.Status.LastOperation = "Orphaned"
 				cloudBucket.Status.ErrorMessage = ""
 				BucketsOrphaned.Inc()
-				r.EventRecorder.Event(cloudBucket, corev1.EventTypeNormal, "BucketOrphaned", fmt.Sprintf("Bucket %s orphaned due to delete policy", cloudBucket.Status.BucketName))
+				r.EventRecorder.Event(cloudBucket, corev1.EventTypeNormal, "BucketOrphaned", fmt.Sprintf("Bucket %s orphaned due to %s", cloudBucket.Status.BucketName, cause))
 			}
 
 			// Remove finalizer
-			controllerutil.RemoveFinalizer(cloudBucket, bucketFinalizer)
-			if err := r.Update(ctx, cloudBucket); err != nil {
+			if err := r.patchFinalizers(ctx, cloudBucket, func(obj client.Object) bool {
+				return controllerutil.RemoveFinalizer(obj, bucketFinalizer)
+			}); client.IgnoreNotFound(err) != nil {
 				log.Error(err, "Failed to remove finalizer")
-				ErrorsTotal.Inc()
+				countError(operationFinalizer, err)
 				r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "FinalizerFailed", fmt.Sprintf("Failed to remove finalizer: %v", err))
 				return ctrl.Result{}, err
 			}
@@ -125,10 +260,11 @@ func (r *CloudBucketReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Add finalizer if not present
 	if !controllerutil.ContainsFinalizer(cloudBucket, bucketFinalizer) {
-		controllerutil.AddFinalizer(cloudBucket, bucketFinalizer)
-		if err := r.Update(ctx, cloudBucket); err != nil {
+		if err := r.patchFinalizers(ctx, cloudBucket, func(obj client.Object) bool {
+			return controllerutil.AddFinalizer(obj, bucketFinalizer)
+		}); err != nil {
 			log.Error(err, "Failed to add finalizer")
-			ErrorsTotal.Inc()
+			countError(operationFinalizer, err)
 			r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "FinalizerFailed", fmt.Sprintf("Failed to add finalizer: %v", err))
 			return ctrl.Result{}, err
 		}
@@ -136,50 +272,91 @@ func (r *CloudBucketReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Generate bucket name if not set
 	if cloudBucket.Status.BucketName == "" {
-		cloudBucket.Status.BucketName = generateBucketName(cloudBucket.Name)
-		if err := r.Status().Update(ctx, cloudBucket); err != nil {
-			log.Error(err, "Failed to update CloudBucket status with bucket name")
-			ErrorsTotal.Inc()
-			r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "StatusUpdateFailed", fmt.Sprintf("Failed to update status: %v", err))
-			return ctrl.Result{}, err
+		bucketName, err := generateBucketName(cloudBucket)
+		if err != nil {
+			log.Error(err, "Invalid bucket name")
+			markFailed(cloudBucket, mygroupv1.ReasonInvalidBucketName, err)
+			ErrorsTotal.WithLabelValues(operationName, mygroupv1.ReasonInvalidBucketName).Inc()
+			r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "InvalidBucketName", err.Error())
+			// Retrying cannot fix an invalid name; wait for the spec to change
+			return ctrl.Result{}, nil
 		}
+		// Persist the name before a bucket is created with it, so a failed status write
+		// cannot leave a bucket behind under a name that is then generated anew
+		log.Info("Generated bucket name", "bucketName", bucketName)
+		cloudBucket.Status.BucketName = bucketName
+		return ctrl.Result{Requeue: true}, nil
 	}
 
-	// Check if bucket exists
-	exists, err := r.bucketExists(ctx, cloudBucket.Status.BucketName)
-	if err != nil {
-		log.Error(err, "Failed to check bucket existence")
-		cloudBucket.Status.LastOperation = "Failed"
-		cloudBucket.Status.ErrorMessage = err.Error()
-		ErrorsTotal.Inc()
-		r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "BucketFailed", fmt.Sprintf("Failed to check bucket existence: %v", err))
-		if updateErr := r.Status().Update(ctx, cloudBucket); updateErr != nil {
-			log.Error(updateErr, "Failed to update CloudBucket status")
-			ErrorsTotal.Inc()
+	// Fetch the bucket to compare against its live state
+	bucket, err := r.getBucket(ctx, cloudBucket.Status.BucketName)
+	if provider.IsAccessDenied(err) && !cloudBucket.Status.BucketExists {
+		// A bucket owned by another project is indistinguishable from a permission problem
+		// until we check whether the name is listed in our own project
+		if taken, listErr := r.isNameTaken(ctx, cloudBucket); listErr == nil && taken {
+			return r.handleNameConflict(ctx, cloudBucket)
 		}
-		return ctrl.Result{RequeueAfter: 30 * time.Second}, err
+	}
+	if err != nil {
+		return r.handleProviderError(ctx, cloudBucket, operationGet, mygroupv1.ReasonGetFailed, "check bucket existence", err)
+	}
+
+	// An observed bucket that does not exist is reported, never created
+	if bucket == nil && !mayCreate(cloudBucket) {
+		log.Info("Observed bucket does not exist", "bucketName", cloudBucket.Status.BucketName)
+		cloudBucket.Status.BucketExists = false
+		markFailed(cloudBucket, mygroupv1.ReasonBucketNotFound,
+			fmt.Errorf("bucket %s does not exist and managementPolicy is %s", cloudBucket.Status.BucketName, managementPolicy(cloudBucket)))
+		return ctrl.Result{RequeueAfter: r.resyncPeriod(cloudBucket)}, nil
 	}
 
 	// If bucket doesn't exist, create it
-	if !exists {
+	if bucket == nil && dryRun {
+		log.Info("Dry run, not creating bucket", "bucketName", cloudBucket.Status.BucketName)
+		cloudBucket.Status.BucketExists = false
+		r.recordPlan(cloudBucket, planCreate(cloudBucket.Spec.ProjectID, r.desiredBucket(cloudBucket)))
+		markObserved(cloudBucket)
+		setCondition(cloudBucket, mygroupv1.ConditionReady, metav1.ConditionFalse, mygroupv1.ReasonDryRun,
+			fmt.Sprintf("Bucket %s does not exist and dry-run mode is enabled", cloudBucket.Status.BucketName))
+		setCondition(cloudBucket, mygroupv1.ConditionSynced, metav1.ConditionFalse, mygroupv1.ReasonDryRun,
+			"Dry run: "+planSummary(cloudBucket.Status.Plan))
+	} else if bucket == nil {
 		log.Info("Creating bucket", "bucketName", cloudBucket.Status.BucketName, "location", cloudBucket.Spec.Location)
-		err = r.createBucket(ctx, cloudBucket.Spec.ProjectID, cloudBucket.Status.BucketName, cloudBucket.Spec.Location, cloudBucket.Spec.Labels)
+		// A bucket observed by a previous reconcile went missing, whatever happened to it since
+		recreating := cloudBucket.Status.BucketExists
+		desired := r.desiredBucket(cloudBucket)
+		err = r.createBucket(ctx, cloudBucket.Spec.ProjectID, desired)
+		if provider.IsAlreadyExists(err) {
+			taken, listErr := r.isNameTaken(ctx, cloudBucket)
+			if listErr == nil && taken {
+				return r.handleNameConflict(ctx, cloudBucket)
+			}
+			if listErr == nil {
+				// The bucket is already ours, e.g. a previous create succeeded but its
+				// response was lost; pick it up as an existing bucket
+				log.Info("Bucket already exists in project", "bucketName", cloudBucket.Status.BucketName)
+				return ctrl.Result{Requeue: true}, nil
+			}
+		}
 		if err != nil {
-			log.Error(err, "Failed to create bucket")
 			cloudBucket.Status.BucketExists = false
-			cloudBucket.Status.LastOperation = "Failed"
-			cloudBucket.Status.ErrorMessage = err.Error()
-			ErrorsTotal.Inc()
-			r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "BucketFailed", fmt.Sprintf("Failed to create bucket: %v", err))
-			if updateErr := r.Status().Update(ctx, cloudBucket); updateErr != nil {
-				log.Error(updateErr, "Failed to update CloudBucket status")
-				ErrorsTotal.Inc()
-			}
-			return ctrl.Result{RequeueAfter: 30 * time.Second}, err
+			return r.handleProviderError(ctx, cloudBucket, operationCreate, mygroupv1.ReasonCreateFailed, "create bucket", err)
 		}
 		cloudBucket.Status.BucketExists = true
-		cloudBucket.Status.AppliedLabels = mergeLabels(cloudBucket.Spec.Labels)
-		if cloudBucket.Status.LastOperation == "Exists" || cloudBucket.Status.LastOperation == "Created" {
+		cloudBucket.Status.AppliedLabels = desired.Labels
+		cloudBucket.Status.ObservedLabels = desired.Labels
+		cloudBucket.Status.Location = desired.Location
+		// GCS creates buckets in STANDARD unless told otherwise
+		cloudBucket.Status.StorageClass = string(mygroupv1.StorageClassStandard)
+		if desired.StorageClass != "" {
+			cloudBucket.Status.StorageClass = desired.StorageClass
+		}
+		cloudBucket.Status.Autoclass = autoclassStatus(desired.Autoclass)
+		cloudBucket.Status.VersioningEnabled = desired.VersioningEnabled
+		cloudBucket.Status.RetentionPolicy = retentionPolicyStatus(desired.RetentionPolicy)
+		cloudBucket.Status.SoftDeletePolicy = softDeletePolicyStatus(desired.SoftDeletePolicy)
+		cloudBucket.Status.DriftedFields = nil
+		if recreating {
 			cloudBucket.Status.LastOperation = "Recreated"
 			BucketsRecreated.Inc()
 			r.EventRecorder.Event(cloudBucket, corev1.EventTypeNormal, "BucketRecreated", "Bucket recreated after being missing")
@@ -188,143 +365,321 @@ func (r *CloudBucketReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			BucketsCreated.Inc()
 			r.EventRecorder.Event(cloudBucket, corev1.EventTypeNormal, "BucketCreated", fmt.Sprintf("Bucket %s created successfully", cloudBucket.Status.BucketName))
 		}
-		cloudBucket.Status.ErrorMessage = ""
+		markReady(cloudBucket, fmt.Sprintf("Bucket %s is available", cloudBucket.Status.BucketName))
+		if desired.RetentionPolicy != nil && cloudBucket.Spec.RetentionPolicy.Locked && cloudBucket.IsRetentionLockConfirmed() {
+			// Lock the retention policy of the new bucket right away
+			return ctrl.Result{Requeue: true}, nil
+		}
 	} else {
-		// Check if labels need updating
-		if !reflect.DeepEqual(cloudBucket.Status.AppliedLabels, mergeLabels(cloudBucket.Spec.Labels)) {
-			log.Info("Updating bucket labels", "bucketName", cloudBucket.Status.BucketName)
-			err = r.updateBucketLabels(ctx, cloudBucket.Status.BucketName, cloudBucket.Spec.Labels)
+		// Without update rights an unbound bucket is only observed, so there is nothing
+		// to check ownership or adoption for
+		observeOnly := !mayUpdate(cloudBucket) && !isBound(cloudBucket)
+
+		// Never touch a bucket whose owner labels name someone else, unless taking it over
+		forceAdopt := !isBound(cloudBucket) && adoptionPolicy(cloudBucket) == mygroupv1.AdoptionPolicyForce
+		if conflict := checkOwnership(r.ClusterID, cloudBucket, bucket); conflict != nil && !forceAdopt && !observeOnly {
+			return r.handleOwnershipConflict(ctx, cloudBucket, bucket, conflict)
+		}
+
+		// A bucket that was not bound to this CloudBucket yet must be adopted first
+		adopted := false
+		if !isBound(cloudBucket) && !observeOnly {
+			refusal, err := r.checkAdoption(ctx, cloudBucket, bucket)
 			if err != nil {
-				log.Error(err, "Failed to update bucket labels")
-				cloudBucket.Status.LastOperation = "Failed"
-				cloudBucket.Status.ErrorMessage = err.Error()
-				ErrorsTotal.Inc()
-				r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "BucketFailed", fmt.Sprintf("Failed to update bucket labels: %v", err))
-				if updateErr := r.Status().Update(ctx, cloudBucket); updateErr != nil {
-					log.Error(updateErr, "Failed to update CloudBucket status")
-					ErrorsTotal.Inc()
-				}
-				return ctrl.Result{RequeueAfter: 30 * time.Second}, err
+				return r.handleProviderError(ctx, cloudBucket, operationGet, mygroupv1.ReasonGetFailed, "check bucket ownership", err)
+			}
+			if refusal != nil {
+				log.Info("Refusing to adopt bucket", "bucketName", cloudBucket.Status.BucketName, "reason", refusal.Error())
+				markFailed(cloudBucket, mygroupv1.ReasonAdoptionRefused, refusal)
+				cloudBucket.Status.Location = bucket.Location
+				cloudBucket.Status.StorageClass = bucket.StorageClass
+				cloudBucket.Status.Autoclass = autoclassStatus(bucket.Autoclass)
+				cloudBucket.Status.VersioningEnabled = bucket.VersioningEnabled
+				cloudBucket.Status.RetentionPolicy = retentionPolicyStatus(bucket.RetentionPolicy)
+				cloudBucket.Status.SoftDeletePolicy = softDeletePolicyStatus(bucket.SoftDeletePolicy)
+				cloudBucket.Status.ObservedLabels = bucket.Labels
+				r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "AdoptionRefused", refusal.Error())
+				// Retrying quickly cannot change the bucket's ownership
+				return ctrl.Result{RequeueAfter: r.resyncPeriod(cloudBucket)}, nil
+			}
+			adopted = !isCreatedFor(cloudBucket, bucket)
+		}
+
+		// Compare every managed field against the live state of the bucket
+		drift := computeDrift(cloudBucket, bucket, r.desiredLabels(cloudBucket))
+		if len(drift.immutable) > 0 {
+			log.Info("Bucket differs from spec on immutable fields", "bucketName", cloudBucket.Status.BucketName, "fields", drift.immutable)
+			r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "DriftDetected",
+				fmt.Sprintf("Bucket %s differs from spec on immutable fields %v", cloudBucket.Status.BucketName, drift.immutable))
+		}
+		var plan []mygroupv1.PlannedAction
+		if !mayUpdate(cloudBucket) {
+			if len(drift.differences()) > 0 {
+				log.Info("Bucket differs from spec, not updating due to management policy", "bucketName", cloudBucket.Status.BucketName,
+					"fields", drift.differences(), "managementPolicy", managementPolicy(cloudBucket))
+			}
+			if managementPolicy(cloudBucket) == mygroupv1.ManagementPolicyObserveOnly {
+				cloudBucket.Status.LastOperation = "Observed"
+			}
+		} else if !drift.update.IsZero() && dryRun {
+			log.Info("Dry run, not updating bucket", "bucketName", cloudBucket.Status.BucketName, "specChanges", drift.specChanges, "drifted", drift.drifted)
+			plan = planUpdate(drift.update)
+		} else if !drift.update.IsZero() {
+			log.Info("Updating bucket", "bucketName", cloudBucket.Status.BucketName, "specChanges", drift.specChanges, "drifted", drift.drifted)
+			bucket, err = r.updateBucket(ctx, cloudBucket.Status.BucketName, drift.update)
+			if err != nil {
+				return r.handleProviderError(ctx, cloudBucket, operationUpdate, mygroupv1.ReasonUpdateFailed, "update bucket", err)
+			}
+			if len(drift.specChanges) > 0 {
+				cloudBucket.Status.LastOperation = updateOperation(drift.specChanges)
+				r.EventRecorder.Event(cloudBucket, corev1.EventTypeNormal, cloudBucket.Status.LastOperation,
+					fmt.Sprintf("Bucket %s updated: %v", cloudBucket.Status.BucketName, drift.specChanges))
+			}
+			if len(drift.drifted) > 0 {
+				cloudBucket.Status.LastOperation = "DriftCorrected"
+				BucketsDriftCorrected.Inc()
+				r.EventRecorder.Event(cloudBucket, corev1.EventTypeNormal, "DriftCorrected",
+					fmt.Sprintf("Bucket %s drift corrected: %v", cloudBucket.Status.BucketName, drift.drifted))
 			}
-			cloudBucket.Status.AppliedLabels = mergeLabels(cloudBucket.Spec.Labels)
-			cloudBucket.Status.LastOperation = "LabelsUpdated"
-			cloudBucket.Status.ErrorMessage = ""
-			r.EventRecorder.Event(cloudBucket, corev1.EventTypeNormal, "LabelsUpdated", fmt.Sprintf("Bucket %s labels updated successfully", cloudBucket.Status.BucketName))
 		} else if cloudBucket.Status.LastOperation == "" {
 			r.EventRecorder.Event(cloudBucket, corev1.EventTypeNormal, "BucketExists", "Bucket already exists")
 			log.Info("Bucket already exists", "bucketName", cloudBucket.Status.BucketName)
 		}
+		if dryRun {
+			r.recordPlan(cloudBucket, plan)
+		}
+		if adopted && !dryRun {
+			log.Info("Adopted existing bucket", "bucketName", cloudBucket.Status.BucketName)
+			cloudBucket.Status.Adopted = true
+			cloudBucket.Status.LastOperation = "Adopted"
+			r.EventRecorder.Event(cloudBucket, corev1.EventTypeNormal, "BucketAdopted",
+				fmt.Sprintf("Existing bucket %s adopted", cloudBucket.Status.BucketName))
+		}
+		cloudBucket.Status.DriftedFields = drift.fields()
+		cloudBucket.Status.Location = bucket.Location
+		cloudBucket.Status.StorageClass = bucket.StorageClass
+		cloudBucket.Status.Autoclass = autoclassStatus(bucket.Autoclass)
+		cloudBucket.Status.VersioningEnabled = bucket.VersioningEnabled
+		cloudBucket.Status.RetentionPolicy = retentionPolicyStatus(bucket.RetentionPolicy)
+		cloudBucket.Status.SoftDeletePolicy = softDeletePolicyStatus(bucket.SoftDeletePolicy)
+		cloudBucket.Status.ObservedLabels = bucket.Labels
 		cloudBucket.Status.BucketExists = true
+		if dryRun && len(cloudBucket.Status.Plan) > 0 {
+			// The planned changes are still outstanding
+			cloudBucket.Status.DriftedFields = drift.differences()
+		} else if mayUpdate(cloudBucket) {
+			cloudBucket.Status.AppliedLabels = r.desiredLabels(cloudBucket)
+		} else {
+			// Nothing was applied, so every difference from the spec is reported
+			cloudBucket.Status.DriftedFields = drift.differences()
+		}
 		// cloudBucket.Status.LastOperation = cloudBucket.Status.LastOperation // Preserve LabelsUpdated or set Exists
-		cloudBucket.Status.ErrorMessage = ""
+		markReady(cloudBucket, fmt.Sprintf("Bucket %s is available", cloudBucket.Status.BucketName))
+		if len(drift.immutable) > 0 {
+			setCondition(cloudBucket, mygroupv1.ConditionSynced, metav1.ConditionFalse, mygroupv1.ReasonImmutableFieldDrift,
+				fmt.Sprintf("Fields %v differ from the spec and cannot be changed on an existing bucket", drift.immutable))
+		}
+		if !mayUpdate(cloudBucket) && len(drift.differences()) > 0 {
+			setCondition(cloudBucket, mygroupv1.ConditionSynced, metav1.ConditionFalse, mygroupv1.ReasonManagementPolicy,
+				fmt.Sprintf("Fields %v differ from the spec and are not updated with managementPolicy %s",
+					drift.differences(), managementPolicy(cloudBucket)))
+		}
+		if retentionLockUnconfirmed(cloudBucket, bucket) {
+			message := fmt.Sprintf("Retention policy of bucket %s is not locked: locking is irreversible and must be confirmed "+
+				"with the %s annotation", cloudBucket.Status.BucketName, mygroupv1.RetentionLockAnnotation)
+			setCondition(cloudBucket, mygroupv1.ConditionSynced, metav1.ConditionFalse, mygroupv1.ReasonRetentionLockUnconfirmed, message)
+			r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, mygroupv1.ReasonRetentionLockUnconfirmed, message)
+		}
+		if len(cloudBucket.Status.Plan) > 0 {
+			setCondition(cloudBucket, mygroupv1.ConditionSynced, metav1.ConditionFalse, mygroupv1.ReasonDryRun,
+				"Dry run: "+planSummary(cloudBucket.Status.Plan))
+		}
 	}
 
-	// Update status
-	if err := r.Status().Update(ctx, cloudBucket); err != nil {
-		log.Error(err, "Failed to update CloudBucket status")
-		ErrorsTotal.Inc()
-		r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "StatusUpdateFailed", fmt.Sprintf("Failed to update status: %v", err))
-		return ctrl.Result{}, err
+	requeueAfter := r.resyncPeriod(cloudBucket)
+	log.Info("Reconciliation completed", "bucketName", cloudBucket.Status.BucketName, "status", cloudBucket.Status, "requeueAfter", requeueAfter)
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// isNameTaken reports whether the bucket name is in use outside of spec.projectID
+func (r *CloudBucketReconciler) isNameTaken(ctx context.Context, cloudBucket *mygroupv1.CloudBucket) (bool, error) {
+	buckets, err := r.Provider.List(ctx, cloudBucket.Spec.ProjectID, cloudBucket.Status.BucketName)
+	if err != nil {
+		return false, err
+	}
+	for _, bucket := range buckets {
+		if bucket.Name == cloudBucket.Status.BucketName {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// handleNameConflict reacts to the bucket name being taken by another project. It picks
+// a new name when the naming strategy generates names, and otherwise reports the conflict
+// and waits for the name to be freed or the CloudBucket to be recreated.
+func (r *CloudBucketReconciler) handleNameConflict(ctx context.Context, cloudBucket *mygroupv1.CloudBucket) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	takenName := cloudBucket.Status.BucketName
+	message := fmt.Sprintf("Bucket name %s is already taken by another project", takenName)
+	setCondition(cloudBucket, mygroupv1.ConditionNameConflict, metav1.ConditionTrue, mygroupv1.ReasonNameTaken, message)
+
+	if namingStrategy(cloudBucket) != mygroupv1.NamingStrategyExact && cloudBucket.Status.NameAttempts < maxNameAttempts {
+		cloudBucket.Status.NameAttempts++
+		bucketName, err := generateBucketName(cloudBucket)
+		if err == nil {
+			log.Info("Bucket name taken, retrying with a new name", "takenName", takenName, "bucketName", bucketName)
+			cloudBucket.Status.BucketName = bucketName
+			r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "NameConflict", fmt.Sprintf("%s, retrying with %s", message, bucketName))
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Failed to generate a new bucket name")
 	}
 
-	log.Info("Reconciliation completed", "bucketName", cloudBucket.Status.BucketName, "status", cloudBucket.Status)
-	return ctrl.Result{}, nil
+	log.Info("Bucket name taken by another project", "bucketName", takenName)
+	markFailed(cloudBucket, mygroupv1.ReasonNameTaken, fmt.Errorf("bucket name %s is already taken by another project", takenName))
+	ErrorsTotal.WithLabelValues(operationCreate, mygroupv1.ReasonNameTaken).Inc()
+	r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "NameConflict", message)
+	// Retrying quickly cannot free up the name, so only check again on the next resync
+	return ctrl.Result{RequeueAfter: r.resyncPeriod(cloudBucket)}, nil
+}
+
+// handleOwnershipConflict reports a bucket whose owner labels name another CloudBucket
+// or cluster and leaves it untouched until the conflict is resolved
+func (r *CloudBucketReconciler) handleOwnershipConflict(ctx context.Context, cloudBucket *mygroupv1.CloudBucket, bucket *provider.Bucket, conflict error) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Bucket is owned by someone else", "bucketName", cloudBucket.Status.BucketName, "reason", conflict.Error())
+	setCondition(cloudBucket, mygroupv1.ConditionOwnershipConflict, metav1.ConditionTrue, mygroupv1.ReasonOwnershipConflict, conflict.Error())
+	markFailed(cloudBucket, mygroupv1.ReasonOwnershipConflict, conflict)
+	cloudBucket.Status.ObservedLabels = bucket.Labels
+	ErrorsTotal.WithLabelValues(operationOwnership, mygroupv1.ReasonOwnershipConflict).Inc()
+	r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "OwnershipConflict", conflict.Error())
+	// Retrying quickly cannot change the bucket's owner
+	return ctrl.Result{RequeueAfter: r.resyncPeriod(cloudBucket)}, nil
+}
+
+// checkBucketOwnership fetches the bucket and checks its owner labels. A missing bucket
+// is not in conflict.
+func (r *CloudBucketReconciler) checkBucketOwnership(ctx context.Context, cloudBucket *mygroupv1.CloudBucket) (conflict error, err error) {
+	bucket, err := r.getBucket(ctx, cloudBucket.Status.BucketName)
+	if err != nil || bucket == nil {
+		return nil, err
+	}
+	return checkOwnership(r.ClusterID, cloudBucket, bucket), nil
+}
+
+// resyncPeriod returns the jittered delay before a CloudBucket is reconciled again
+func (r *CloudBucketReconciler) resyncPeriod(cloudBucket *mygroupv1.CloudBucket) time.Duration {
+	period := r.ResyncPeriod
+	if cloudBucket.Spec.ResyncPeriod != nil {
+		period = cloudBucket.Spec.ResyncPeriod.Duration
+	}
+	if period <= 0 {
+		return 0
+	}
+	return wait.Jitter(period, resyncJitterFactor)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *CloudBucketReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.EventRecorder = mgr.GetEventRecorderFor("cloud-storage-controller")
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&mygroupv1.CloudBucket{}).
+		For(&mygroupv1.CloudBucket{}, builder.WithPredicates(cloudBucketPredicates())).
+		WithOptions(controller.Options{RateLimiter: newRateLimiter()}).
 		Complete(r)
 }
 
-// generateBucketName creates a unique bucket name based on the CloudBucket name
-func generateBucketName(name string) string {
-	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
-	const suffixLength = 8
-	rand.Seed(time.Now().UnixNano())
-	suffix := make([]byte, suffixLength)
-	for i := range suffix {
-		suffix[i] = charset[rand.Intn(len(charset))]
-	}
-	// Ensure lowercase and no invalid characters for GCS
-	name = strings.ToLower(strings.ReplaceAll(name, "_", "-"))
-	return fmt.Sprintf("%s-%s", name, string(suffix))
+// Label identifying buckets managed by this controller
+const (
+	managedByLabel = "managed-by"
+	managedByValue = "cloud-storage-controller"
+)
+
+// desiredLabels returns the labels the bucket of a CloudBucket should carry
+func (r *CloudBucketReconciler) desiredLabels(cloudBucket *mygroupv1.CloudBucket) map[string]string {
+	return mergeLabels(cloudBucket.Spec.Labels, ownerLabels(r.ClusterID, cloudBucket))
 }
 
-// mergeLabels combines user labels with the managed-by label
-func mergeLabels(userLabels map[string]string) map[string]string {
+// mergeLabels combines user labels with the managed-by and owner labels, which take precedence
+func mergeLabels(userLabels, owner map[string]string) map[string]string {
 	labels := make(map[string]string)
 	for k, v := range userLabels {
 		labels[k] = v
 	}
-	labels["managed-by"] = "cloud-storage-controller"
+	for k, v := range owner {
+		labels[k] = v
+	}
+	labels[managedByLabel] = managedByValue
 	return labels
 }
 
-// createBucket creates a new bucket in GCS
-func (r *CloudBucketReconciler) createBucket(ctx context.Context, projectID, bucketName, location string, labels map[string]string) error {
-	if bucketName == "" {
-		return fmt.Errorf("bucket name cannot be empty")
+// desiredBucket returns the bucket the spec of a CloudBucket describes
+func (r *CloudBucketReconciler) desiredBucket(cloudBucket *mygroupv1.CloudBucket) *provider.Bucket {
+	bucket := &provider.Bucket{
+		Name:     cloudBucket.Status.BucketName,
+		Location: cloudBucket.Spec.Location,
+		Labels:   r.desiredLabels(cloudBucket),
 	}
-	bucket := r.GCSClient.Bucket(bucketName)
-	attrs := &storage.BucketAttrs{
-		Labels: mergeLabels(labels),
+	if cloudBucket.Spec.StorageClass != "" {
+		bucket.StorageClass = string(cloudBucket.Spec.StorageClass)
 	}
-	if location != "" {
-		attrs.Location = location
+	if autoclassEnabled(cloudBucket) {
+		bucket.Autoclass = toProviderAutoclass(cloudBucket.Spec.Autoclass)
 	}
-	if err := bucket.Create(ctx, projectID, attrs); err != nil {
-		return fmt.Errorf("Bucket(%q).Create: %v", bucketName, err)
+	if cloudBucket.Spec.Versioning != nil {
+		bucket.VersioningEnabled = cloudBucket.Spec.Versioning.Enabled
 	}
-	return nil
+	if cloudBucket.Spec.Lifecycle != nil {
+		bucket.Lifecycle = toProviderLifecycle(cloudBucket.Spec.Lifecycle)
+	}
+	// A retention policy can only be locked once the bucket exists
+	if policy := cloudBucket.Spec.RetentionPolicy; policy != nil && retentionPeriod(policy) > 0 {
+		bucket.RetentionPolicy = &provider.RetentionPolicy{Period: retentionPeriod(policy)}
+	}
+	if policy := cloudBucket.Spec.SoftDeletePolicy; policy != nil {
+		bucket.SoftDeletePolicy = &provider.SoftDeletePolicy{RetentionDuration: softDeleteRetention(policy)}
+	}
+	return bucket
 }
 
-// updateBucketLabels updates the labels of an existing GCS bucket
-func (r *CloudBucketReconciler) updateBucketLabels(ctx context.Context, bucketName string, labels map[string]string) error {
-	if bucketName == "" {
+// createBucket creates a new bucket through the provider
+func (r *CloudBucketReconciler) createBucket(ctx context.Context, projectID string, bucket *provider.Bucket) error {
+	if bucket.Name == "" {
 		return fmt.Errorf("bucket name cannot be empty")
 	}
-	bucket := r.GCSClient.Bucket(bucketName)
-	attrs, err := bucket.Attrs(ctx)
-	if err != nil {
-		return fmt.Errorf("Bucket(%q).Attrs: %v", bucketName, err)
-	}
-	// Update labels
-	attrs.Labels = mergeLabels(labels)
-	// Use minimal BucketAttrsToUpdate to avoid unsupported fields
-	_, err = bucket.Update(ctx, storage.BucketAttrsToUpdate{})
-	if err != nil {
-		return fmt.Errorf("Bucket(%q).Update: %v", bucketName, err)
+	return r.Provider.Create(ctx, projectID, bucket)
+}
+
+// updateBucket applies changes to an existing bucket and returns its new state
+func (r *CloudBucketReconciler) updateBucket(ctx context.Context, bucketName string, update provider.BucketUpdate) (*provider.Bucket, error) {
+	if bucketName == "" {
+		return nil, fmt.Errorf("bucket name cannot be empty")
 	}
-	return nil
+	return r.Provider.Update(ctx, bucketName, update)
 }
 
-// deleteBucket deletes a bucket in GCS
+// deleteBucket deletes a bucket through the provider; a bucket that is already gone counts as deleted
 func (r *CloudBucketReconciler) deleteBucket(ctx context.Context, bucketName string) error {
 	if bucketName == "" {
 		return fmt.Errorf("bucket name cannot be empty")
 	}
-	bucket := r.GCSClient.Bucket(bucketName)
-	if err := bucket.Delete(ctx); err != nil {
-		return fmt.Errorf("Bucket(%q).Delete: %v", bucketName, err)
+	if err := r.Provider.Delete(ctx, bucketName); err != nil && !provider.IsNotFound(err) {
+		return err
 	}
 	return nil
 }
 
-// bucketExists checks if a bucket exists in GCS
-func (r *CloudBucketReconciler) bucketExists(ctx context.Context, bucketName string) (bool, error) {
+// getBucket fetches the live state of a bucket, returning nil if it does not exist
+func (r *CloudBucketReconciler) getBucket(ctx context.Context, bucketName string) (*provider.Bucket, error) {
 	if bucketName == "" {
-		return false, fmt.Errorf("bucket name cannot be empty")
+		return nil, fmt.Errorf("bucket name cannot be empty")
 	}
-	bucket := r.GCSClient.Bucket(bucketName)
-	_, err := bucket.Attrs(ctx)
+	bucket, err := r.Provider.Get(ctx, bucketName)
 	if err != nil {
-		if err == storage.ErrBucketNotExist {
-			return false, nil
+		if provider.IsNotFound(err) {
+			return nil, nil
 		}
-		return false, fmt.Errorf("Bucket(%q).Attrs: %v", bucketName, err)
+		return nil, err
 	}
-	return true, nil
+	return bucket, nil
 }
@@ -18,18 +18,81 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+	"github.com/andreistefanciprian/cloud-storage-controller/internal/provider"
 )
 
+// drainEvents returns the events recorded so far by a FakeRecorder
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+// writeTrackingClient counts status writes and can change the object right before the
+// next patch or status patch, to simulate a concurrent writer
+type writeTrackingClient struct {
+	client.Client
+	statusWrites      int
+	beforePatch       func()
+	beforeStatusPatch func()
+}
+
+func (c *writeTrackingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if c.beforePatch != nil {
+		beforePatch := c.beforePatch
+		c.beforePatch = nil
+		beforePatch()
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *writeTrackingClient) Status() client.SubResourceWriter {
+	return &statusWriteCounter{SubResourceWriter: c.Client.Status(), client: c}
+}
+
+type statusWriteCounter struct {
+	client.SubResourceWriter
+	client *writeTrackingClient
+}
+
+func (w *statusWriteCounter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	w.client.statusWrites++
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+func (w *statusWriteCounter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	w.client.statusWrites++
+	if w.client.beforeStatusPatch != nil {
+		beforeStatusPatch := w.client.beforeStatusPatch
+		w.client.beforeStatusPatch = nil
+		beforeStatusPatch()
+	}
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
+
 var _ = Describe("CloudBucket Controller", func() {
 	Context("When reconciling a resource", func() {
 		const resourceName = "test-resource"
@@ -38,47 +101,1417 @@ var _ = Describe("CloudBucket Controller", func() {
 
 		typeNamespacedName := types.NamespacedName{
 			Name:      resourceName,
-			Namespace: "default", // TODO(user):Modify as needed
+			Namespace: "default",
 		}
-		cloudbucket := &mygroupv1.CloudBucket{}
 
-		BeforeEach(func() {
-			By("creating the custom resource for the Kind CloudBucket")
-			err := k8sClient.Get(ctx, typeNamespacedName, cloudbucket)
-			if err != nil && errors.IsNotFound(err) {
-				resource := &mygroupv1.CloudBucket{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      resourceName,
-						Namespace: "default",
-					},
-					// TODO(user): Specify other spec details if needed.
+		var (
+			fakeProvider         *provider.FakeProvider
+			recorder             *record.FakeRecorder
+			controllerReconciler *CloudBucketReconciler
+		)
+
+		// reconcileResult reconciles until no immediate requeue is requested, as the
+		// controller would, e.g. after a generated bucket name was persisted
+		reconcileResult := func() (reconcile.Result, error) {
+			for i := 0; i < 5; i++ {
+				result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+				if err != nil || !result.Requeue {
+					return result, err
 				}
-				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+			return reconcile.Result{}, fmt.Errorf("still requeueing after 5 reconciles")
+		}
+
+		reconcileResource := func() error {
+			_, err := reconcileResult()
+			return err
+		}
+
+		getResource := func() *mygroupv1.CloudBucket {
+			resource := &mygroupv1.CloudBucket{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			return resource
+		}
+
+		createResource := func(deletePolicy string) {
+			resource := &mygroupv1.CloudBucket{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: mygroupv1.CloudBucketSpec{
+					ProjectID:    "test-project",
+					DeletePolicy: deletePolicy,
+					Location:     "EU",
+					Labels:       map[string]string{"env": "test"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			fakeProvider = provider.NewFakeProvider()
+			recorder = record.NewFakeRecorder(100)
+			controllerReconciler = &CloudBucketReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				Provider:      fakeProvider,
+				EventRecorder: recorder,
+				ClusterID:     "test-cluster",
 			}
 		})
 
 		AfterEach(func() {
-			// TODO(user): Cleanup logic after each test, like removing the resource instance.
 			resource := &mygroupv1.CloudBucket{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			if errors.IsNotFound(err) {
+				return
+			}
 			Expect(err).NotTo(HaveOccurred())
 
 			By("Cleanup the specific resource instance CloudBucket")
-			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			resource.Finalizers = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			err = k8sClient.Delete(ctx, resource)
+			Expect(client.IgnoreNotFound(err)).To(Succeed())
 		})
-		It("should successfully reconcile the resource", func() {
+
+		It("should create the bucket with the spec labels", func() {
+			createResource("Delete")
+
 			By("Reconciling the created resource")
-			controllerReconciler := &CloudBucketReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+			Expect(reconcileResource()).To(Succeed())
+
+			resource := getResource()
+			Expect(resource.Finalizers).To(ContainElement("cloudbuckets.mygroup.example.com/finalizer"))
+			Expect(resource.Status.BucketName).NotTo(BeEmpty())
+			Expect(resource.Status.BucketExists).To(BeTrue())
+			Expect(resource.Status.LastOperation).To(Equal("Created"))
+			Expect(resource.Status.ErrorMessage).To(BeEmpty())
+			Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, mygroupv1.ConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, mygroupv1.ConditionSynced)).To(BeTrue())
+
+			bucket, ok := fakeProvider.Bucket(resource.Status.BucketName)
+			Expect(ok).To(BeTrue())
+			Expect(bucket.Location).To(Equal("EU"))
+			Expect(bucket.Labels).To(Equal(map[string]string{
+				"env":                   "test",
+				"managed-by":            "cloud-storage-controller",
+				"cloudbucket-cluster":   "test-cluster",
+				"cloudbucket-namespace": "default",
+				"cloudbucket-name":      resourceName,
+				"cloudbucket-uid":       uidHash(resource),
+			}))
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("BucketCreated")))
+		})
+
+		It("should persist a generated name before creating the bucket and write status once per reconcile", func() {
+			trackingClient := &writeTrackingClient{Client: k8sClient}
+			controllerReconciler.Client = trackingClient
+			createResource("Delete")
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+			Expect(trackingClient.statusWrites).To(Equal(1))
+			resource := getResource()
+			Expect(resource.Status.BucketName).NotTo(BeEmpty())
+			Expect(resource.Finalizers).To(ContainElement("cloudbuckets.mygroup.example.com/finalizer"))
+			Expect(fakeProvider.Calls(provider.OperationCreate)).To(BeZero())
+
+			By("Creating the bucket under the persisted name")
+			Expect(reconcileResource()).To(Succeed())
+			Expect(trackingClient.statusWrites).To(Equal(2))
+			_, ok := fakeProvider.Bucket(resource.Status.BucketName)
+			Expect(ok).To(BeTrue())
+
+			By("Not writing an unchanged status")
+			Expect(reconcileResource()).To(Succeed())
+			Expect(trackingClient.statusWrites).To(Equal(2))
+		})
+
+		It("should not overwrite a bucket name persisted concurrently", func() {
+			trackingClient := &writeTrackingClient{Client: k8sClient}
+			controllerReconciler.Client = trackingClient
+			createResource("Delete")
+			trackingClient.beforeStatusPatch = func() {
+				resource := getResource()
+				resource.Status.BucketName = "concurrent-bucket"
+				Expect(k8sClient.Status().Update(ctx, resource)).To(Succeed())
+			}
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+			Expect(getResource().Status.BucketName).To(Equal("concurrent-bucket"))
+
+			By("Creating the bucket under the name that was persisted first")
+			Expect(reconcileResource()).To(Succeed())
+			Expect(fakeProvider.Calls(provider.OperationCreate)).To(Equal(1))
+			_, ok := fakeProvider.Bucket("concurrent-bucket")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should keep finalizers added concurrently when adding its own", func() {
+			trackingClient := &writeTrackingClient{Client: k8sClient}
+			controllerReconciler.Client = trackingClient
+			createResource("Delete")
+			trackingClient.beforePatch = func() {
+				resource := getResource()
+				resource.Finalizers = append(resource.Finalizers, "example.com/other")
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			}
+
+			Expect(reconcileResource()).To(Succeed())
+			resource := getResource()
+			Expect(resource.Finalizers).To(ConsistOf("example.com/other", "cloudbuckets.mygroup.example.com/finalizer"))
+			Expect(resource.Status.LastOperation).To(Equal("Created"))
+		})
+
+		It("should create the bucket with the exact name from the spec", func() {
+			resource := &mygroupv1.CloudBucket{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: mygroupv1.CloudBucketSpec{
+					ProjectID:  "test-project",
+					BucketName: "acme-test-resource",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+
+			Expect(getResource().Status.BucketName).To(Equal("acme-test-resource"))
+			_, ok := fakeProvider.Bucket("acme-test-resource")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should refuse to call the provider with an invalid bucket name", func() {
+			resource := &mygroupv1.CloudBucket{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: mygroupv1.CloudBucketSpec{
+					ProjectID:  "test-project",
+					BucketName: "google-test-resource",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+
+			resource = getResource()
+			Expect(resource.Status.BucketName).To(BeEmpty())
+			Expect(resource.Status.LastOperation).To(Equal("Failed"))
+			ready := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(mygroupv1.ReasonInvalidBucketName))
+			Expect(fakeProvider.Calls(provider.OperationGet)).To(BeZero())
+			Expect(fakeProvider.Calls(provider.OperationCreate)).To(BeZero())
+		})
+
+		It("should pick a new name when a generated name is taken by another project", func() {
+			createResource("Delete")
+			takenName, err := generateBucketName(getResource())
+			Expect(err).NotTo(HaveOccurred())
+			fakeProvider.AddForeignBucket(takenName)
+
+			Expect(reconcileResource()).To(Succeed())
+			resource := getResource()
+			Expect(resource.Status.BucketName).NotTo(Equal(takenName))
+			Expect(resource.Status.NameAttempts).To(Equal(int32(1)))
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("NameConflict")))
+
+			By("Creating the bucket under the new name")
+			Expect(resource.Status.LastOperation).To(Equal("Created"))
+			Expect(meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionNameConflict)).To(BeNil())
+			_, ok := fakeProvider.Bucket(resource.Status.BucketName)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should report a conflict for an exact name taken by another project", func() {
+			resource := &mygroupv1.CloudBucket{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: mygroupv1.CloudBucketSpec{
+					ProjectID:    "test-project",
+					BucketName:   "acme-test-resource",
+					DeletePolicy: "Delete",
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			fakeProvider.AddForeignBucket("acme-test-resource")
+
+			result, err := reconcileResult()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+
+			resource = getResource()
+			Expect(resource.Status.BucketName).To(Equal("acme-test-resource"))
+			Expect(resource.Status.LastOperation).To(Equal("Failed"))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, mygroupv1.ConditionNameConflict)).To(BeTrue())
+			ready := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(mygroupv1.ReasonNameTaken))
+			Expect(fakeProvider.Calls(provider.OperationCreate)).To(BeZero())
+
+			By("Leaving the other project's bucket alone on deletion")
+			Expect(k8sClient.Delete(ctx, getResource())).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			Expect(fakeProvider.Calls(provider.OperationDelete)).To(BeZero())
+		})
+
+		Context("When adopting an existing bucket", func() {
+			createAdoptingResource := func(adoptionPolicy string) {
+				resource := &mygroupv1.CloudBucket{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+					Spec: mygroupv1.CloudBucketSpec{
+						ProjectID:      "test-project",
+						BucketName:     "acme-existing",
+						AdoptionPolicy: adoptionPolicy,
+						DeletePolicy:   "Delete",
+						Labels:         map[string]string{"env": "test"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+
+			It("should adopt the bucket and import its attributes", func() {
+				fakeProvider.AddBucket("test-project", &provider.Bucket{
+					Name:     "acme-existing",
+					Location: "EU",
+					Labels:   map[string]string{"team": "data"},
+				})
+				createAdoptingResource(mygroupv1.AdoptionPolicyAdopt)
+				Expect(reconcileResource()).To(Succeed())
+
+				resource := getResource()
+				Expect(resource.Status.Adopted).To(BeTrue())
+				Expect(resource.Status.BucketExists).To(BeTrue())
+				Expect(resource.Status.LastOperation).To(Equal("Adopted"))
+				Expect(resource.Status.Location).To(Equal("EU"))
+				Expect(resource.Status.ObservedLabels).To(HaveKeyWithValue("team", "data"))
+				Expect(resource.Status.ObservedLabels).To(HaveKeyWithValue("env", "test"))
+				Expect(resource.Status.ObservedLabels).To(HaveKeyWithValue("cloudbucket-uid", uidHash(resource)))
+				Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, mygroupv1.ConditionReady)).To(BeTrue())
+				Expect(fakeProvider.Calls(provider.OperationCreate)).To(BeZero())
+				Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("BucketAdopted")))
+			})
+
+			It("should refuse to adopt when adoptionPolicy is Never", func() {
+				fakeProvider.AddBucket("test-project", &provider.Bucket{Name: "acme-existing", Location: "EU"})
+				createAdoptingResource(mygroupv1.AdoptionPolicyNever)
+				Expect(reconcileResource()).To(Succeed())
+
+				resource := getResource()
+				Expect(resource.Status.BucketExists).To(BeFalse())
+				ready := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Reason).To(Equal(mygroupv1.ReasonAdoptionRefused))
+				Expect(fakeProvider.Calls(provider.OperationUpdate)).To(BeZero())
+				Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("AdoptionRefused")))
+
+				By("Leaving the bucket alone on deletion")
+				Expect(k8sClient.Delete(ctx, getResource())).To(Succeed())
+				Expect(reconcileResource()).To(Succeed())
+				Expect(fakeProvider.Calls(provider.OperationDelete)).To(BeZero())
+				Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("not being bound to this CloudBucket")))
+			})
+
+			It("should refuse to adopt a bucket labelled by another controller when adoptionPolicy is Never", func() {
+				fakeProvider.AddBucket("test-project", &provider.Bucket{
+					Name:     "acme-existing",
+					Location: "EU",
+					Labels:   map[string]string{"managed-by": "cloud-storage-controller"},
+				})
+				createAdoptingResource(mygroupv1.AdoptionPolicyNever)
+				Expect(reconcileResource()).To(Succeed())
+
+				resource := getResource()
+				Expect(resource.Status.BucketExists).To(BeFalse())
+				Expect(resource.Status.ErrorMessage).To(ContainSubstring("adoptionPolicy is Never"))
+				Expect(fakeProvider.Calls(provider.OperationUpdate)).To(BeZero())
+			})
+
+			It("should refuse a bucket from another project even if it carries our owner labels", func() {
+				createAdoptingResource(mygroupv1.AdoptionPolicyNever)
+				fakeProvider.AddBucket("other-project", &provider.Bucket{
+					Name:     "acme-existing",
+					Location: "EU",
+					Labels:   map[string]string{"managed-by": "cloud-storage-controller", "cloudbucket-uid": uidHash(getResource())},
+				})
+				Expect(reconcileResource()).To(Succeed())
+
+				resource := getResource()
+				Expect(resource.Status.BucketExists).To(BeFalse())
+				Expect(resource.Status.ErrorMessage).To(ContainSubstring("does not belong to project test-project"))
+				Expect(fakeProvider.Calls(provider.OperationUpdate)).To(BeZero())
+			})
+
+			It("should refuse to adopt a bucket from another project", func() {
+				fakeProvider.AddBucket("other-project", &provider.Bucket{Name: "acme-existing", Location: "EU"})
+				createAdoptingResource(mygroupv1.AdoptionPolicyForce)
+				Expect(reconcileResource()).To(Succeed())
+
+				resource := getResource()
+				Expect(resource.Status.Adopted).To(BeFalse())
+				Expect(resource.Status.ErrorMessage).To(ContainSubstring("does not belong to project test-project"))
+				Expect(fakeProvider.Calls(provider.OperationUpdate)).To(BeZero())
+			})
+
+			It("should only take over a bucket managed by another owner when forced", func() {
+				fakeProvider.AddBucket("test-project", &provider.Bucket{
+					Name:     "acme-existing",
+					Location: "EU",
+					Labels:   map[string]string{"managed-by": "terraform"},
+				})
+				createAdoptingResource(mygroupv1.AdoptionPolicyAdopt)
+				Expect(reconcileResource()).To(Succeed())
+				Expect(getResource().Status.ErrorMessage).To(ContainSubstring("managed by terraform"))
+				Expect(fakeProvider.Calls(provider.OperationUpdate)).To(BeZero())
+
+				By("Forcing the adoption")
+				resource := getResource()
+				resource.Spec.AdoptionPolicy = mygroupv1.AdoptionPolicyForce
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(reconcileResource()).To(Succeed())
+
+				Expect(getResource().Status.Adopted).To(BeTrue())
+				bucket, ok := fakeProvider.Bucket("acme-existing")
+				Expect(ok).To(BeTrue())
+				Expect(bucket.Labels).To(HaveKeyWithValue("managed-by", "cloud-storage-controller"))
+			})
+		})
+
+		It("should not touch a bucket owned by another CloudBucket", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+			drainEvents(recorder)
+
+			By("Handing the bucket to another owner")
+			_, err := fakeProvider.Update(ctx, bucketName, provider.BucketUpdate{
+				SetLabels: map[string]string{"cloudbucket-uid": "0123456789abcdef", "env": "other"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			updates := fakeProvider.Calls(provider.OperationUpdate)
+			Expect(reconcileResource()).To(Succeed())
+
+			resource := getResource()
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, mygroupv1.ConditionOwnershipConflict)).To(BeTrue())
+			ready := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(mygroupv1.ReasonOwnershipConflict))
+			Expect(fakeProvider.Calls(provider.OperationUpdate)).To(Equal(updates))
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("OwnershipConflict")))
+
+			By("Orphaning the bucket instead of deleting it")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			Expect(fakeProvider.Calls(provider.OperationDelete)).To(BeZero())
+			_, ok := fakeProvider.Bucket(bucketName)
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should not touch a bucket owned by another cluster", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+
+			controllerReconciler.ClusterID = "other-cluster"
+			Expect(reconcileResource()).To(Succeed())
+
+			resource := getResource()
+			condition := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionOwnershipConflict)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Message).To(ContainSubstring("owned by cluster test-cluster"))
+			Expect(fakeProvider.Calls(provider.OperationUpdate)).To(BeZero())
+		})
+
+		It("should stamp owner labels on a bucket that has none", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+
+			_, err := fakeProvider.Update(ctx, bucketName, provider.BucketUpdate{
+				DeleteLabels: []string{"cloudbucket-cluster", "cloudbucket-namespace", "cloudbucket-name", "cloudbucket-uid"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconcileResource()).To(Succeed())
+
+			resource := getResource()
+			Expect(meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionOwnershipConflict)).To(BeNil())
+			bucket, ok := fakeProvider.Bucket(bucketName)
+			Expect(ok).To(BeTrue())
+			Expect(bucket.Labels).To(HaveKeyWithValue("cloudbucket-uid", uidHash(resource)))
+			Expect(bucket.Labels).To(HaveKeyWithValue("cloudbucket-cluster", "test-cluster"))
+		})
+
+		Context("When the management policy limits changes", func() {
+			createManagedResource := func(managementPolicy string) {
+				resource := &mygroupv1.CloudBucket{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+					Spec: mygroupv1.CloudBucketSpec{
+						ProjectID:        "test-project",
+						BucketName:       "acme-legacy",
+						ManagementPolicy: managementPolicy,
+						DeletePolicy:     "Delete",
+						Location:         "EU",
+						Labels:           map[string]string{"env": "prod"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+
+			It("should only report an observed bucket", func() {
+				fakeProvider.AddBucket("test-project", &provider.Bucket{
+					Name:     "acme-legacy",
+					Location: "EU",
+					Labels:   map[string]string{"env": "legacy"},
+				})
+				createManagedResource(mygroupv1.ManagementPolicyObserveOnly)
+				Expect(reconcileResource()).To(Succeed())
+
+				resource := getResource()
+				Expect(resource.Status.BucketExists).To(BeTrue())
+				Expect(resource.Status.LastOperation).To(Equal("Observed"))
+				Expect(resource.Status.Location).To(Equal("EU"))
+				Expect(resource.Status.ObservedLabels).To(Equal(map[string]string{"env": "legacy"}))
+				Expect(resource.Status.DriftedFields).To(ContainElement("labels.env"))
+				Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, mygroupv1.ConditionReady)).To(BeTrue())
+				synced := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionSynced)
+				Expect(synced).NotTo(BeNil())
+				Expect(synced.Reason).To(Equal(mygroupv1.ReasonManagementPolicy))
+				Expect(fakeProvider.Calls(provider.OperationUpdate)).To(BeZero())
+
+				By("Orphaning the bucket despite deletePolicy Delete")
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+				Expect(reconcileResource()).To(Succeed())
+				Expect(fakeProvider.Calls(provider.OperationDelete)).To(BeZero())
+				_, ok := fakeProvider.Bucket("acme-legacy")
+				Expect(ok).To(BeTrue())
+			})
+
+			It("should report a missing observed bucket without creating it", func() {
+				createManagedResource(mygroupv1.ManagementPolicyObserveOnly)
+				Expect(reconcileResource()).To(Succeed())
+
+				ready := meta.FindStatusCondition(getResource().Status.Conditions, mygroupv1.ConditionReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Reason).To(Equal(mygroupv1.ReasonBucketNotFound))
+				Expect(fakeProvider.Calls(provider.OperationCreate)).To(BeZero())
+			})
+
+			It("should run the adoption checks when switching an observed bucket to Full", func() {
+				fakeProvider.AddBucket("test-project", &provider.Bucket{Name: "acme-legacy", Location: "EU"})
+				createManagedResource(mygroupv1.ManagementPolicyObserveOnly)
+				Expect(reconcileResource()).To(Succeed())
+
+				resource := getResource()
+				resource.Spec.ManagementPolicy = mygroupv1.ManagementPolicyFull
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(reconcileResource()).To(Succeed())
+
+				ready := meta.FindStatusCondition(getResource().Status.Conditions, mygroupv1.ConditionReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Reason).To(Equal(mygroupv1.ReasonAdoptionRefused))
+				Expect(fakeProvider.Calls(provider.OperationUpdate)).To(BeZero())
+			})
+
+			It("should create a missing bucket but never update or delete it with CreateOnly", func() {
+				createManagedResource(mygroupv1.ManagementPolicyCreateOnly)
+				Expect(reconcileResource()).To(Succeed())
+				Expect(getResource().Status.LastOperation).To(Equal("Created"))
+
+				By("Changing the spec labels")
+				resource := getResource()
+				resource.Spec.Labels = map[string]string{"env": "staging"}
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(reconcileResource()).To(Succeed())
+
+				resource = getResource()
+				Expect(fakeProvider.Calls(provider.OperationUpdate)).To(BeZero())
+				Expect(resource.Status.DriftedFields).To(Equal([]string{"labels.env"}))
+				synced := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionSynced)
+				Expect(synced).NotTo(BeNil())
+				Expect(synced.Reason).To(Equal(mygroupv1.ReasonManagementPolicy))
+
+				By("Orphaning the bucket on deletion")
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+				Expect(reconcileResource()).To(Succeed())
+				Expect(fakeProvider.Calls(provider.OperationDelete)).To(BeZero())
+			})
+		})
+
+		It("should update the bucket labels when the spec changes", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+
+			By("Changing the spec labels")
+			resource := getResource()
+			resource.Spec.Labels = map[string]string{"env": "prod"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+
+			resource = getResource()
+			Expect(resource.Status.LastOperation).To(Equal("LabelsUpdated"))
+			Expect(resource.Status.AppliedLabels).To(HaveKeyWithValue("env", "prod"))
+
+			bucket, ok := fakeProvider.Bucket(resource.Status.BucketName)
+			Expect(ok).To(BeTrue())
+			Expect(bucket.Labels).To(HaveKeyWithValue("env", "prod"))
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("LabelsUpdated")))
+		})
+
+		It("should remove labels dropped from the spec and keep foreign labels", func() {
+			createResource("Delete")
+			resource := getResource()
+			resource.Spec.Labels = map[string]string{"env": "test", "team": "storage"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+
+			By("Adding a label outside of the controller")
+			_, err := fakeProvider.Update(ctx, bucketName, provider.BucketUpdate{
+				SetLabels: map[string]string{"cost-center": "1234"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Dropping a label from the spec")
+			resource = getResource()
+			resource.Spec.Labels = map[string]string{"env": "test"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+
+			bucket, ok := fakeProvider.Bucket(bucketName)
+			Expect(ok).To(BeTrue())
+			Expect(bucket.Labels).NotTo(HaveKey("team"))
+			Expect(bucket.Labels).To(HaveKeyWithValue("cost-center", "1234"))
+			Expect(getResource().Status.AppliedLabels).NotTo(HaveKey("team"))
+			Expect(getResource().Status.AppliedLabels).To(Equal(controllerReconciler.desiredLabels(getResource())))
+		})
+
+		It("should correct labels changed outside of the controller", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+			drainEvents(recorder)
+
+			By("Changing a managed label outside of the controller")
+			_, err := fakeProvider.Update(ctx, bucketName, provider.BucketUpdate{
+				SetLabels: map[string]string{"env": "tampered"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconcileResource()).To(Succeed())
+
+			bucket, ok := fakeProvider.Bucket(bucketName)
+			Expect(ok).To(BeTrue())
+			Expect(bucket.Labels).To(HaveKeyWithValue("env", "test"))
+			resource := getResource()
+			Expect(resource.Status.LastOperation).To(Equal("DriftCorrected"))
+			Expect(resource.Status.DriftedFields).To(Equal([]string{"labels.env"}))
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("DriftCorrected")))
+
+			By("Leaving the bucket alone when nothing drifted")
+			updates := fakeProvider.Calls(provider.OperationUpdate)
+			Expect(reconcileResource()).To(Succeed())
+			Expect(fakeProvider.Calls(provider.OperationUpdate)).To(Equal(updates))
+			Expect(getResource().Status.DriftedFields).To(BeEmpty())
+		})
+
+		It("should set the storage class and keep it set", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+			bucket, _ := fakeProvider.Bucket(bucketName)
+			Expect(bucket.StorageClass).To(Equal("STANDARD"))
+			Expect(getResource().Status.StorageClass).To(Equal("STANDARD"))
+
+			By("Changing the storage class in the spec")
+			resource := getResource()
+			resource.Spec.StorageClass = mygroupv1.StorageClassNearline
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucket, _ = fakeProvider.Bucket(bucketName)
+			Expect(bucket.StorageClass).To(Equal("NEARLINE"))
+			Expect(getResource().Status.StorageClass).To(Equal("NEARLINE"))
+
+			By("Changing the storage class outside of the controller")
+			_, err := fakeProvider.Update(ctx, bucketName, provider.BucketUpdate{StorageClass: "COLDLINE"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconcileResource()).To(Succeed())
+			bucket, _ = fakeProvider.Bucket(bucketName)
+			Expect(bucket.StorageClass).To(Equal("NEARLINE"))
+			resource = getResource()
+			Expect(resource.Status.LastOperation).To(Equal("DriftCorrected"))
+			Expect(resource.Status.DriftedFields).To(Equal([]string{"storageClass"}))
+		})
+
+		It("should enable Autoclass and leave the storage class to it", func() {
+			resource := &mygroupv1.CloudBucket{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: mygroupv1.CloudBucketSpec{
+					ProjectID:    "test-project",
+					StorageClass: mygroupv1.StorageClassStandard,
+					Autoclass: &mygroupv1.AutoclassSpec{
+						Enabled:              true,
+						TerminalStorageClass: mygroupv1.StorageClassArchive,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+			bucket, _ := fakeProvider.Bucket(bucketName)
+			Expect(bucket.Autoclass).To(Equal(&provider.Autoclass{Enabled: true, TerminalStorageClass: "ARCHIVE"}))
+			Expect(getResource().Status.Autoclass).To(Equal(&mygroupv1.AutoclassStatus{Enabled: true, TerminalStorageClass: "ARCHIVE"}))
+
+			By("Not correcting the storage class while Autoclass is enabled")
+			_, err := fakeProvider.Update(ctx, bucketName, provider.BucketUpdate{StorageClass: "NEARLINE"})
+			Expect(err).NotTo(HaveOccurred())
+			updates := fakeProvider.Calls(provider.OperationUpdate)
+			Expect(reconcileResource()).To(Succeed())
+			Expect(fakeProvider.Calls(provider.OperationUpdate)).To(Equal(updates))
+
+			By("Disabling Autoclass in the spec")
+			resource = getResource()
+			resource.Spec.Autoclass = &mygroupv1.AutoclassSpec{Enabled: false}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucket, _ = fakeProvider.Bucket(bucketName)
+			Expect(bucket.Autoclass).To(BeNil())
+			Expect(getResource().Status.Autoclass).To(BeNil())
+		})
+
+		It("should enable versioning and keep it enabled", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+			bucket, _ := fakeProvider.Bucket(bucketName)
+			Expect(bucket.VersioningEnabled).To(BeFalse())
+
+			By("Enabling versioning in the spec")
+			resource := getResource()
+			resource.Spec.Versioning = &mygroupv1.VersioningSpec{Enabled: true}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+
+			bucket, _ = fakeProvider.Bucket(bucketName)
+			Expect(bucket.VersioningEnabled).To(BeTrue())
+			resource = getResource()
+			Expect(resource.Status.VersioningEnabled).To(BeTrue())
+			Expect(resource.Status.LastOperation).To(Equal("Updated"))
+
+			By("Disabling versioning outside of the controller")
+			disabled := false
+			_, err := fakeProvider.Update(ctx, bucketName, provider.BucketUpdate{VersioningEnabled: &disabled})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconcileResource()).To(Succeed())
+
+			bucket, _ = fakeProvider.Bucket(bucketName)
+			Expect(bucket.VersioningEnabled).To(BeTrue())
+			resource = getResource()
+			Expect(resource.Status.LastOperation).To(Equal("DriftCorrected"))
+			Expect(resource.Status.DriftedFields).To(Equal([]string{"versioning.enabled"}))
+		})
+
+		It("should create the bucket with versioning and leave it alone when unset", func() {
+			resource := &mygroupv1.CloudBucket{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: mygroupv1.CloudBucketSpec{
+					ProjectID:  "test-project",
+					Versioning: &mygroupv1.VersioningSpec{Enabled: true},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+			bucket, _ := fakeProvider.Bucket(bucketName)
+			Expect(bucket.VersioningEnabled).To(BeTrue())
+
+			By("Removing versioning from the spec")
+			resource = getResource()
+			resource.Spec.Versioning = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucket, _ = fakeProvider.Bucket(bucketName)
+			Expect(bucket.VersioningEnabled).To(BeTrue())
+			Expect(getResource().Status.VersioningEnabled).To(BeTrue())
+		})
+
+		It("should apply lifecycle rules, correct them and leave them alone when unset", func() {
+			age := int64(30)
+			resource := &mygroupv1.CloudBucket{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: mygroupv1.CloudBucketSpec{
+					ProjectID: "test-project",
+					Lifecycle: &mygroupv1.LifecycleSpec{Rules: []mygroupv1.LifecycleRule{
+						{
+							Action: mygroupv1.LifecycleAction{
+								Type:         mygroupv1.LifecycleActionSetStorageClass,
+								StorageClass: mygroupv1.StorageClassNearline,
+							},
+							Condition: mygroupv1.LifecycleCondition{Age: &age},
+						},
+						{
+							Action:    mygroupv1.LifecycleAction{Type: mygroupv1.LifecycleActionDelete},
+							Condition: mygroupv1.LifecycleCondition{MatchesPrefix: []string{"tmp/"}},
+						},
+					}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+			bucket, _ := fakeProvider.Bucket(bucketName)
+			Expect(bucket.Lifecycle.Rules).To(Equal([]provider.LifecycleRule{
+				{Action: "SetStorageClass", StorageClass: "NEARLINE", Condition: provider.LifecycleCondition{AgeInDays: &age}},
+				{Action: "Delete", Condition: provider.LifecycleCondition{MatchesPrefix: []string{"tmp/"}}},
+			}))
+
+			By("Removing the rules outside of the controller")
+			_, err := fakeProvider.Update(ctx, bucketName, provider.BucketUpdate{Lifecycle: &provider.Lifecycle{}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconcileResource()).To(Succeed())
+
+			bucket, _ = fakeProvider.Bucket(bucketName)
+			Expect(bucket.Lifecycle.Rules).To(HaveLen(2))
+			resource = getResource()
+			Expect(resource.Status.LastOperation).To(Equal("DriftCorrected"))
+			Expect(resource.Status.DriftedFields).To(Equal([]string{"lifecycle"}))
+
+			By("Removing lifecycle from the spec")
+			resource.Spec.Lifecycle = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucket, _ = fakeProvider.Bucket(bucketName)
+			Expect(bucket.Lifecycle.Rules).To(HaveLen(2))
+		})
+
+		It("should lock the retention policy only once the lock is confirmed", func() {
+			resource := &mygroupv1.CloudBucket{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: mygroupv1.CloudBucketSpec{
+					ProjectID: "test-project",
+					RetentionPolicy: &mygroupv1.RetentionPolicySpec{
+						Period: metav1.Duration{Duration: 720 * time.Hour},
+						Locked: true,
+					},
+				},
 			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+			bucket, _ := fakeProvider.Bucket(bucketName)
+			Expect(bucket.RetentionPolicy).NotTo(BeNil())
+			Expect(bucket.RetentionPolicy.Period).To(Equal(720 * time.Hour))
+			Expect(bucket.RetentionPolicy.IsLocked).To(BeFalse())
 
-			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
-				NamespacedName: typeNamespacedName,
+			By("Reconciling the existing bucket without the confirmation annotation")
+			Expect(reconcileResource()).To(Succeed())
+			bucket, _ = fakeProvider.Bucket(bucketName)
+			Expect(bucket.RetentionPolicy.IsLocked).To(BeFalse())
+			resource = getResource()
+			synced := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionSynced)
+			Expect(synced).NotTo(BeNil())
+			Expect(synced.Reason).To(Equal(mygroupv1.ReasonRetentionLockUnconfirmed))
+			Expect(resource.Status.RetentionPolicy.IsLocked).To(BeFalse())
+
+			By("Confirming the lock")
+			resource.Annotations = map[string]string{mygroupv1.RetentionLockAnnotation: "true"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucket, _ = fakeProvider.Bucket(bucketName)
+			Expect(bucket.RetentionPolicy.IsLocked).To(BeTrue())
+			resource = getResource()
+			Expect(resource.Status.RetentionPolicy.IsLocked).To(BeTrue())
+			Expect(resource.Status.RetentionPolicy.Period.Duration).To(Equal(720 * time.Hour))
+			Expect(resource.Status.RetentionPolicy.EffectiveTime).NotTo(BeNil())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, mygroupv1.ConditionSynced)).To(BeTrue())
+		})
+
+		It("should lock the retention policy of a new bucket right away when confirmed", func() {
+			resource := &mygroupv1.CloudBucket{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        resourceName,
+					Namespace:   "default",
+					Annotations: map[string]string{mygroupv1.RetentionLockAnnotation: "true"},
+				},
+				Spec: mygroupv1.CloudBucketSpec{
+					ProjectID: "test-project",
+					RetentionPolicy: &mygroupv1.RetentionPolicySpec{
+						Period: metav1.Duration{Duration: 48 * time.Hour},
+						Locked: true,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucket, _ := fakeProvider.Bucket(getResource().Status.BucketName)
+			Expect(bucket.RetentionPolicy.IsLocked).To(BeTrue())
+			Expect(getResource().Status.RetentionPolicy.IsLocked).To(BeTrue())
+		})
+
+		It("should disable soft delete and correct its retention duration", func() {
+			resource := &mygroupv1.CloudBucket{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: mygroupv1.CloudBucketSpec{
+					ProjectID:        "test-project",
+					SoftDeletePolicy: &mygroupv1.SoftDeletePolicySpec{},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+			bucket, _ := fakeProvider.Bucket(bucketName)
+			Expect(bucket.SoftDeletePolicy).NotTo(BeNil())
+			Expect(bucket.SoftDeletePolicy.RetentionDuration).To(BeZero())
+			Expect(getResource().Status.SoftDeletePolicy).NotTo(BeNil())
+
+			By("Re-enabling soft delete outside of the controller")
+			week := 7 * 24 * time.Hour
+			_, err := fakeProvider.Update(ctx, bucketName, provider.BucketUpdate{SoftDeleteRetention: &week})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconcileResource()).To(Succeed())
+
+			bucket, _ = fakeProvider.Bucket(bucketName)
+			Expect(bucket.SoftDeletePolicy.RetentionDuration).To(BeZero())
+			resource = getResource()
+			Expect(resource.Status.LastOperation).To(Equal("DriftCorrected"))
+			Expect(resource.Status.DriftedFields).To(Equal([]string{"softDeletePolicy.retentionDuration"}))
+			Expect(resource.Status.SoftDeletePolicy.RetentionDuration.Duration).To(BeZero())
+			Expect(resource.Status.SoftDeletePolicy.EffectiveTime).NotTo(BeNil())
+
+			By("Changing the retention duration in the spec")
+			resource.Spec.SoftDeletePolicy.RetentionDuration = metav1.Duration{Duration: 30 * 24 * time.Hour}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucket, _ = fakeProvider.Bucket(bucketName)
+			Expect(bucket.SoftDeletePolicy.RetentionDuration).To(Equal(30 * 24 * time.Hour))
+			Expect(getResource().Status.SoftDeletePolicy.RetentionDuration.Duration).To(Equal(30 * 24 * time.Hour))
+		})
+
+		It("should report drift on immutable fields without touching them", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+			drainEvents(recorder)
+
+			By("Replacing the bucket with one in another location")
+			fakeProvider.DeleteOutOfBand(bucketName)
+			fakeProvider.AddBucket("test-project", &provider.Bucket{
+				Name:     bucketName,
+				Location: "US",
+				Labels:   getResource().Status.AppliedLabels,
 			})
+			Expect(reconcileResource()).To(Succeed())
+
+			resource := getResource()
+			Expect(resource.Status.Location).To(Equal("US"))
+			Expect(resource.Status.DriftedFields).To(Equal([]string{"location"}))
+			synced := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionSynced)
+			Expect(synced).NotTo(BeNil())
+			Expect(synced.Reason).To(Equal(mygroupv1.ReasonImmutableFieldDrift))
+			Expect(fakeProvider.Calls(provider.OperationUpdate)).To(BeZero())
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("DriftDetected")))
+		})
+
+		It("should recreate a bucket deleted out-of-band", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+
+			By("Deleting the bucket outside of the controller")
+			bucketName := getResource().Status.BucketName
+			fakeProvider.DeleteOutOfBand(bucketName)
+			Expect(reconcileResource()).To(Succeed())
+
+			resource := getResource()
+			Expect(resource.Status.LastOperation).To(Equal("Recreated"))
+			Expect(resource.Status.BucketExists).To(BeTrue())
+			_, ok := fakeProvider.Bucket(bucketName)
+			Expect(ok).To(BeTrue())
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("BucketRecreated")))
+		})
+
+		It("should report a recreate after the labels were updated", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+
+			resource := getResource()
+			resource.Spec.Labels = map[string]string{"env": "prod"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			Expect(getResource().Status.LastOperation).To(Equal("LabelsUpdated"))
+			drainEvents(recorder)
+
+			By("Deleting the bucket outside of the controller")
+			created := testutil.ToFloat64(BucketsCreated)
+			recreated := testutil.ToFloat64(BucketsRecreated)
+			fakeProvider.DeleteOutOfBand(getResource().Status.BucketName)
+			Expect(reconcileResource()).To(Succeed())
+
+			Expect(getResource().Status.LastOperation).To(Equal("Recreated"))
+			Expect(testutil.ToFloat64(BucketsRecreated)).To(Equal(recreated + 1))
+			Expect(testutil.ToFloat64(BucketsCreated)).To(Equal(created))
+			events := drainEvents(recorder)
+			Expect(events).To(ContainElement(ContainSubstring("BucketRecreated")))
+			Expect(events).NotTo(ContainElement(ContainSubstring("BucketCreated")))
+		})
+
+		It("should requeue after the jittered resync period", func() {
+			createResource("Delete")
+			controllerReconciler.ResyncPeriod = time.Minute
+
+			result, err := reconcileResult()
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+			Expect(result.RequeueAfter).To(BeNumerically(">=", time.Minute))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", time.Minute+6*time.Second))
+
+			By("Overriding the resync period in the spec")
+			resource := getResource()
+			resource.Spec.ResyncPeriod = &metav1.Duration{Duration: 5 * time.Minute}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+
+			result, err = reconcileResult()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">=", 5*time.Minute))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", 5*time.Minute+30*time.Second))
+		})
+
+		It("should delete the bucket when deletePolicy is Delete", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+
+			By("Deleting the custom resource")
+			Expect(k8sClient.Delete(ctx, getResource())).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+
+			_, ok := fakeProvider.Bucket(bucketName)
+			Expect(ok).To(BeFalse())
+			err := k8sClient.Get(ctx, typeNamespacedName, &mygroupv1.CloudBucket{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("BucketDeleted")))
+		})
+
+		It("should report a failed bucket deletion in the Deleting condition", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+			fakeProvider.FailOn(provider.OperationDelete, fmt.Errorf("permission denied"))
+
+			By("Deleting the custom resource")
+			Expect(k8sClient.Delete(ctx, getResource())).To(Succeed())
+			Expect(reconcileResource()).To(MatchError(ContainSubstring("permission denied")))
+
+			resource := getResource()
+			Expect(resource.Finalizers).To(ContainElement("cloudbuckets.mygroup.example.com/finalizer"))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, mygroupv1.ConditionDeleting)).To(BeTrue())
+			synced := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionSynced)
+			Expect(synced).NotTo(BeNil())
+			Expect(synced.Reason).To(Equal(mygroupv1.ReasonDeleteFailed))
+			ready := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(mygroupv1.ReasonDeleting))
+		})
+
+		It("should not delete the bucket when its owner cannot be checked", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+			fakeProvider.FailOn(provider.OperationGet, fmt.Errorf("backend unavailable"))
+
+			By("Deleting the custom resource")
+			Expect(k8sClient.Delete(ctx, getResource())).To(Succeed())
+			Expect(reconcileResource()).To(MatchError(ContainSubstring("backend unavailable")))
+			Expect(fakeProvider.Calls(provider.OperationDelete)).To(BeZero())
+
+			resource := getResource()
+			Expect(resource.Finalizers).To(ContainElement("cloudbuckets.mygroup.example.com/finalizer"))
+			deleting := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionDeleting)
+			Expect(deleting).NotTo(BeNil())
+			Expect(deleting.Reason).To(Equal(mygroupv1.ReasonOwnershipCheckFailed))
+			synced := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionSynced)
+			Expect(synced).NotTo(BeNil())
+			Expect(synced.Reason).To(Equal(mygroupv1.ReasonOwnershipCheckFailed))
+		})
+
+		It("should treat a bucket that is already gone as deleted", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+			fakeProvider.DeleteOutOfBand(getResource().Status.BucketName)
+
+			Expect(k8sClient.Delete(ctx, getResource())).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			err := k8sClient.Get(ctx, typeNamespacedName, &mygroupv1.CloudBucket{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should refuse to delete a non-empty bucket when deletePolicy is Delete", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+			fakeProvider.AddObjects(getResource().Status.BucketName, provider.Object{Name: "data.csv", Generation: 1})
+
+			Expect(k8sClient.Delete(ctx, getResource())).To(Succeed())
+			// Retrying cannot empty the bucket, so the failure is reported without an error
+			Expect(reconcileResource()).To(Succeed())
+			Expect(fakeProvider.Calls(provider.OperationDeleteObject)).To(BeZero())
+			resource := getResource()
+			Expect(resource.Finalizers).To(ContainElement("cloudbuckets.mygroup.example.com/finalizer"))
+			Expect(resource.Status.ErrorMessage).To(ContainSubstring("bucket not empty"))
+			synced := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionSynced)
+			Expect(synced).NotTo(BeNil())
+			Expect(synced.Reason).To(Equal(mygroupv1.ReasonDeleteFailed))
+			ready := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(mygroupv1.ReasonDeleting))
 		})
+
+		It("should purge every object version before deleting with DeleteWithContents", func() {
+			createResource(mygroupv1.DeletePolicyDeleteWithContents)
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+			for i := 0; i < 2500; i++ {
+				fakeProvider.AddObjects(bucketName,
+					provider.Object{Name: fmt.Sprintf("logs/%04d.json", i), Generation: 1},
+					provider.Object{Name: fmt.Sprintf("logs/%04d.json", i), Generation: 2})
+			}
+
+			By("Failing part way through the purge")
+			fakeProvider.FailOn(provider.OperationDeleteObject, fmt.Errorf("backend unavailable"))
+			Expect(k8sClient.Delete(ctx, getResource())).To(Succeed())
+			Expect(reconcileResource()).To(MatchError(ContainSubstring("backend unavailable")))
+
+			resource := getResource()
+			Expect(resource.Status.Purge).NotTo(BeNil())
+			Expect(resource.Status.Purge.Completed).To(BeFalse())
+			Expect(resource.Finalizers).To(ContainElement("cloudbuckets.mygroup.example.com/finalizer"))
+			Expect(fakeProvider.Objects(bucketName)).To(HaveLen(5000))
+
+			By("Resuming the purge")
+			fakeProvider.ClearFailures()
+			Expect(reconcileResource()).To(Succeed())
+			Expect(fakeProvider.Objects(bucketName)).To(BeEmpty())
+			_, ok := fakeProvider.Bucket(bucketName)
+			Expect(ok).To(BeFalse())
+			err := k8sClient.Get(ctx, typeNamespacedName, &mygroupv1.CloudBucket{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should keep a protected bucket until deletion protection is disabled", func() {
+			createResource("Delete")
+			resource := getResource()
+			resource.Spec.DeletionProtection = true
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+
+			By("Deleting the custom resource")
+			Expect(k8sClient.Delete(ctx, getResource())).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+
+			resource = getResource()
+			Expect(resource.Finalizers).To(ContainElement("cloudbuckets.mygroup.example.com/finalizer"))
+			blocked := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionDeletionBlocked)
+			Expect(blocked).NotTo(BeNil())
+			Expect(blocked.Reason).To(Equal(mygroupv1.ReasonDeletionProtected))
+			Expect(fakeProvider.Calls(provider.OperationDelete)).To(BeZero())
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("DeletionBlocked")))
+
+			By("Keeping the protection through the annotation alone")
+			resource.Spec.DeletionProtection = false
+			resource.Annotations = map[string]string{mygroupv1.DeletionProtectionAnnotation: "true"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			Expect(fakeProvider.Calls(provider.OperationDelete)).To(BeZero())
+
+			By("Disabling deletion protection")
+			resource = getResource()
+			resource.Annotations = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+
+			_, ok := fakeProvider.Bucket(bucketName)
+			Expect(ok).To(BeFalse())
+			err := k8sClient.Get(ctx, typeNamespacedName, &mygroupv1.CloudBucket{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should not touch the bucket while paused and catch up on resume", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+			drainEvents(recorder)
+
+			By("Pausing and changing both the spec and the bucket")
+			resource := getResource()
+			resource.Annotations = map[string]string{mygroupv1.PausedAnnotation: "true"}
+			resource.Spec.Labels = map[string]string{"env": "test", "team": "data"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			_, err := fakeProvider.Update(ctx, bucketName, provider.BucketUpdate{SetLabels: map[string]string{"env": "tampered"}})
+			Expect(err).NotTo(HaveOccurred())
+			calls := fakeProvider.Calls(provider.OperationGet) + fakeProvider.Calls(provider.OperationUpdate)
+			Expect(reconcileResource()).To(Succeed())
+
+			resource = getResource()
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, mygroupv1.ConditionPaused)).To(BeTrue())
+			Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+			Expect(fakeProvider.Calls(provider.OperationGet) + fakeProvider.Calls(provider.OperationUpdate)).To(Equal(calls))
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("Paused")))
+
+			By("Resuming")
+			resource.Annotations = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+
+			resource = getResource()
+			Expect(meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionPaused)).To(BeNil())
+			Expect(resource.Status.DriftedFields).To(ContainElement("labels.env"))
+			bucket, ok := fakeProvider.Bucket(bucketName)
+			Expect(ok).To(BeTrue())
+			Expect(bucket.Labels).To(HaveKeyWithValue("env", "test"))
+			Expect(bucket.Labels).To(HaveKeyWithValue("team", "data"))
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("Resumed")))
+		})
+
+		It("should keep the finalizer and the bucket when deleted while paused", func() {
+			createResource("Delete")
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+
+			resource := getResource()
+			resource.Annotations = map[string]string{mygroupv1.PausedAnnotation: "true"}
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+
+			Expect(getResource().Finalizers).To(ContainElement("cloudbuckets.mygroup.example.com/finalizer"))
+			Expect(fakeProvider.Calls(provider.OperationDelete)).To(BeZero())
+
+			By("Resuming")
+			resource = getResource()
+			resource.Annotations = nil
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+			_, ok := fakeProvider.Bucket(bucketName)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should not create a bucket for a CloudBucket paused from the start", func() {
+			resource := &mygroupv1.CloudBucket{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        resourceName,
+					Namespace:   "default",
+					Annotations: map[string]string{mygroupv1.PausedAnnotation: "true"},
+				},
+				Spec: mygroupv1.CloudBucketSpec{ProjectID: "test-project"},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+
+			Expect(meta.IsStatusConditionTrue(getResource().Status.Conditions, mygroupv1.ConditionPaused)).To(BeTrue())
+			Expect(fakeProvider.Calls(provider.OperationCreate)).To(BeZero())
+		})
+
+		Context("When dry-run mode is enabled", func() {
+			It("should plan the creation of a bucket without creating it", func() {
+				controllerReconciler.DryRun = true
+				createResource("Delete")
+				Expect(reconcileResource()).To(Succeed())
+
+				resource := getResource()
+				Expect(fakeProvider.Calls(provider.OperationCreate)).To(BeZero())
+				Expect(resource.Status.BucketExists).To(BeFalse())
+				Expect(resource.Status.Plan).To(HaveLen(1))
+				Expect(resource.Status.Plan[0].Action).To(Equal(mygroupv1.PlannedActionCreate))
+				Expect(resource.Status.Plan[0].Description).To(ContainSubstring(resource.Status.BucketName))
+				Expect(resource.Status.Plan[0].Description).To(ContainSubstring("env=test"))
+				synced := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionSynced)
+				Expect(synced).NotTo(BeNil())
+				Expect(synced.Reason).To(Equal(mygroupv1.ReasonDryRun))
+				Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+				Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("DryRun")))
+
+				By("Not repeating the event for an unchanged plan")
+				Expect(reconcileResource()).To(Succeed())
+				Expect(drainEvents(recorder)).NotTo(ContainElement(ContainSubstring("DryRun")))
+
+				By("Creating the bucket once dry-run mode is disabled")
+				controllerReconciler.DryRun = false
+				Expect(reconcileResource()).To(Succeed())
+				resource = getResource()
+				Expect(resource.Status.BucketExists).To(BeTrue())
+				Expect(resource.Status.Plan).To(BeEmpty())
+			})
+
+			It("should plan label changes for an annotated CloudBucket", func() {
+				createResource("Delete")
+				Expect(reconcileResource()).To(Succeed())
+				bucketName := getResource().Status.BucketName
+
+				resource := getResource()
+				resource.Annotations = map[string]string{mygroupv1.DryRunAnnotation: "true"}
+				resource.Spec.Labels = map[string]string{"team": "data"}
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(reconcileResource()).To(Succeed())
+
+				resource = getResource()
+				Expect(fakeProvider.Calls(provider.OperationUpdate)).To(BeZero())
+				Expect(resource.Status.Plan).To(Equal([]mygroupv1.PlannedAction{
+					{Action: mygroupv1.PlannedActionUpdate, Field: "labels.team", Description: `set to "data"`},
+					{Action: mygroupv1.PlannedActionUpdate, Field: "labels.env", Description: "remove"},
+				}))
+				Expect(resource.Status.DriftedFields).To(ConsistOf("labels.env", "labels.team"))
+				bucket, _ := fakeProvider.Bucket(bucketName)
+				Expect(bucket.Labels).To(HaveKeyWithValue("env", "test"))
+			})
+
+			It("should plan the deletion of a bucket and keep the finalizer", func() {
+				createResource("DeleteWithContents")
+				Expect(reconcileResource()).To(Succeed())
+				bucketName := getResource().Status.BucketName
+				fakeProvider.AddObjects(bucketName, provider.Object{Name: "a", Generation: 1})
+
+				controllerReconciler.DryRun = true
+				Expect(k8sClient.Delete(ctx, getResource())).To(Succeed())
+				Expect(reconcileResource()).To(Succeed())
+
+				resource := getResource()
+				Expect(resource.Finalizers).To(ContainElement("cloudbuckets.mygroup.example.com/finalizer"))
+				Expect(resource.Status.Plan).To(HaveLen(2))
+				Expect(resource.Status.Plan[0].Action).To(Equal(mygroupv1.PlannedActionDeleteContents))
+				Expect(resource.Status.Plan[1].Action).To(Equal(mygroupv1.PlannedActionDelete))
+				Expect(fakeProvider.Calls(provider.OperationDeleteObject)).To(BeZero())
+				Expect(fakeProvider.Calls(provider.OperationDelete)).To(BeZero())
+				Expect(fakeProvider.Objects(bucketName)).To(HaveLen(1))
+			})
+		})
+
+		It("should orphan the bucket when deletePolicy is Orphan", func() {
+			createResource("Orphan")
+			Expect(reconcileResource()).To(Succeed())
+			bucketName := getResource().Status.BucketName
+
+			By("Deleting the custom resource")
+			Expect(k8sClient.Delete(ctx, getResource())).To(Succeed())
+			Expect(reconcileResource()).To(Succeed())
+
+			_, ok := fakeProvider.Bucket(bucketName)
+			Expect(ok).To(BeTrue())
+			Expect(fakeProvider.Calls(provider.OperationDelete)).To(BeZero())
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("BucketOrphaned")))
+		})
+
+		It("should not retry permanent provider errors before the next resync", func() {
+			createResource("Delete")
+			controllerReconciler.ResyncPeriod = time.Minute
+			fakeProvider.FailOn(provider.OperationCreate, &provider.Error{
+				Reason: provider.ReasonPermissionDenied,
+				Err:    fmt.Errorf("storage.buckets.create denied"),
+			})
+			before := testutil.ToFloat64(ErrorsTotal.WithLabelValues(operationCreate, string(provider.ReasonPermissionDenied)))
+
+			result, err := reconcileResult()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">=", time.Minute))
+
+			resource := getResource()
+			ready := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(string(provider.ReasonPermissionDenied)))
+			synced := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionSynced)
+			Expect(synced).NotTo(BeNil())
+			Expect(synced.Reason).To(Equal(mygroupv1.ReasonCreateFailed))
+			Expect(testutil.ToFloat64(ErrorsTotal.WithLabelValues(operationCreate, string(provider.ReasonPermissionDenied)))).To(Equal(before + 1))
+		})
+
+		It("should return transient provider errors to be retried with backoff", func() {
+			createResource("Delete")
+			controllerReconciler.ResyncPeriod = time.Minute
+			fakeProvider.FailOn(provider.OperationCreate, &provider.Error{
+				Reason: provider.ReasonRateLimited,
+				Err:    fmt.Errorf("rate limit exceeded"),
+			})
+
+			result, err := reconcileResult()
+			Expect(err).To(MatchError(ContainSubstring("rate limit exceeded")))
+			Expect(result.RequeueAfter).To(BeZero())
+			ready := meta.FindStatusCondition(getResource().Status.Conditions, mygroupv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(string(provider.ReasonRateLimited)))
+		})
+
+		It("should report provider failures in status and events", func() {
+			createResource("Delete")
+			fakeProvider.FailOn(provider.OperationCreate, fmt.Errorf("quota exceeded"))
+
+			Expect(reconcileResource()).To(MatchError(ContainSubstring("quota exceeded")))
+
+			resource := getResource()
+			Expect(resource.Status.BucketExists).To(BeFalse())
+			Expect(resource.Status.LastOperation).To(Equal("Failed"))
+			Expect(resource.Status.ErrorMessage).To(ContainSubstring("quota exceeded"))
+			synced := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionSynced)
+			Expect(synced).NotTo(BeNil())
+			Expect(synced.Status).To(Equal(metav1.ConditionFalse))
+			Expect(synced.Reason).To(Equal(mygroupv1.ReasonCreateFailed))
+			ready := meta.FindStatusCondition(resource.Status.Conditions, mygroupv1.ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(string(provider.ReasonUnknown)))
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring("BucketFailed")))
+
+			By("Recovering once the provider succeeds again")
+			fakeProvider.ClearFailures()
+			Expect(reconcileResource()).To(Succeed())
+			Expect(getResource().Status.LastOperation).To(Equal("Created"))
+		})
+	})
+})
+
+var _ = Describe("CloudBucket event filtering", func() {
+	var old *mygroupv1.CloudBucket
+
+	BeforeEach(func() {
+		old = &mygroupv1.CloudBucket{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test-resource",
+				Namespace:  "default",
+				Generation: 1,
+				Finalizers: []string{bucketFinalizer},
+			},
+			Spec: mygroupv1.CloudBucketSpec{ProjectID: "test-project"},
+		}
+	})
+
+	passes := func(updated *mygroupv1.CloudBucket) bool {
+		return cloudBucketPredicates().Update(event.UpdateEvent{ObjectOld: old, ObjectNew: updated})
+	}
+
+	It("should ignore status-only updates", func() {
+		updated := old.DeepCopy()
+		updated.ResourceVersion = "2"
+		updated.Status.LastOperation = "Created"
+		updated.Status.BucketExists = true
+		Expect(passes(updated)).To(BeFalse())
+	})
+
+	It("should pass spec, annotation and label changes", func() {
+		updated := old.DeepCopy()
+		updated.Generation = 2
+		Expect(passes(updated)).To(BeTrue())
+
+		updated = old.DeepCopy()
+		updated.Annotations = map[string]string{mygroupv1.PausedAnnotation: "true"}
+		Expect(passes(updated)).To(BeTrue())
+
+		updated = old.DeepCopy()
+		updated.Labels = map[string]string{"team": "data"}
+		Expect(passes(updated)).To(BeTrue())
+	})
+
+	It("should pass finalizer and deletion changes", func() {
+		updated := old.DeepCopy()
+		updated.Finalizers = nil
+		Expect(passes(updated)).To(BeTrue())
+
+		updated = old.DeepCopy()
+		now := metav1.Now()
+		updated.DeletionTimestamp = &now
+		Expect(passes(updated)).To(BeTrue())
 	})
 })
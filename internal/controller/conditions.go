@@ -0,0 +1,63 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+)
+
+// setCondition sets a status condition stamped with the CloudBucket's generation
+func setCondition(cloudBucket *mygroupv1.CloudBucket, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&cloudBucket.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cloudBucket.Generation,
+	})
+}
+
+// markObserved records that the current generation was seen, on paths that report their
+// outcome in conditions without making the bucket match the spec
+func markObserved(cloudBucket *mygroupv1.CloudBucket) {
+	cloudBucket.Status.ObservedGeneration = cloudBucket.Generation
+}
+
+// markReady records a successful reconciliation in the status and conditions
+func markReady(cloudBucket *mygroupv1.CloudBucket, message string) {
+	cloudBucket.Status.ErrorMessage = ""
+	markObserved(cloudBucket)
+	setCondition(cloudBucket, mygroupv1.ConditionReady, metav1.ConditionTrue, mygroupv1.ReasonAvailable, message)
+	setCondition(cloudBucket, mygroupv1.ConditionSynced, metav1.ConditionTrue, mygroupv1.ReasonReconcileSuccess, "Bucket matches the spec")
+	meta.RemoveStatusCondition(&cloudBucket.Status.Conditions, mygroupv1.ConditionNameConflict)
+	meta.RemoveStatusCondition(&cloudBucket.Status.Conditions, mygroupv1.ConditionOwnershipConflict)
+}
+
+// markFailed records a failed operation in the status and conditions, using a
+// reason derived from the operation that failed
+func markFailed(cloudBucket *mygroupv1.CloudBucket, reason string, err error) {
+	cloudBucket.Status.LastOperation = "Failed"
+	cloudBucket.Status.ErrorMessage = err.Error()
+	markObserved(cloudBucket)
+	setCondition(cloudBucket, mygroupv1.ConditionSynced, metav1.ConditionFalse, reason, err.Error())
+	if cloudBucket.GetDeletionTimestamp() == nil {
+		setCondition(cloudBucket, mygroupv1.ConditionReady, metav1.ConditionFalse, reason, err.Error())
+	}
+}
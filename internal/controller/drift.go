@@ -0,0 +1,203 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+	"github.com/andreistefanciprian/cloud-storage-controller/internal/provider"
+)
+
+// bucketDrift describes how the live state of a bucket differs from its CloudBucket spec
+type bucketDrift struct {
+	// update brings the bucket back in line with the spec
+	update provider.BucketUpdate
+	// specChanges lists fields whose desired value changed since the controller last applied it
+	specChanges []string
+	// drifted lists fields that were changed on the bucket outside of the controller
+	drifted []string
+	// immutable lists fields that differ from the spec but cannot be changed on an existing bucket
+	immutable []string
+}
+
+// fields returns the drifted and immutable fields, sorted, for reporting in status
+func (d bucketDrift) fields() []string {
+	if len(d.drifted) == 0 && len(d.immutable) == 0 {
+		return nil
+	}
+	fields := append(append([]string{}, d.drifted...), d.immutable...)
+	sort.Strings(fields)
+	return fields
+}
+
+// differences returns every field that differs from the spec, sorted, whether or not it
+// can be corrected
+func (d bucketDrift) differences() []string {
+	fields := append(append(append([]string{}, d.specChanges...), d.drifted...), d.immutable...)
+	if len(fields) == 0 {
+		return nil
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// addChange records a field that differs from the spec: as drift if the bucket matched the
+// spec when it was last observed, and as a change of the spec otherwise
+func (d *bucketDrift) addChange(field string, matchedBefore bool) {
+	if matchedBefore {
+		d.drifted = append(d.drifted, field)
+	} else {
+		d.specChanges = append(d.specChanges, field)
+	}
+}
+
+// computeDrift diffs every managed field of the CloudBucket spec against the live bucket
+func computeDrift(cloudBucket *mygroupv1.CloudBucket, bucket *provider.Bucket, desiredLabels map[string]string) bucketDrift {
+	var drift bucketDrift
+
+	// Labels: a label that was applied with its current desired value and no longer
+	// matches has drifted; anything else is a change of the spec.
+	applied := cloudBucket.Status.AppliedLabels
+	drift.update.SetLabels, drift.update.DeleteLabels = diffLabels(bucket.Labels, desiredLabels, applied)
+	for _, k := range sortedKeys(drift.update.SetLabels) {
+		if v, ok := applied[k]; ok && v == desiredLabels[k] {
+			drift.drifted = append(drift.drifted, "labels."+k)
+		} else {
+			drift.specChanges = append(drift.specChanges, "labels."+k)
+		}
+	}
+	for _, k := range drift.update.DeleteLabels {
+		drift.specChanges = append(drift.specChanges, "labels."+k)
+	}
+
+	// The storage class is only managed when set in the spec, and left to Autoclass while it
+	// is enabled; GCS reports it upper-cased.
+	if storageClass := string(cloudBucket.Spec.StorageClass); storageClass != "" && !autoclassEnabled(cloudBucket) &&
+		!strings.EqualFold(storageClass, bucket.StorageClass) {
+		drift.update.StorageClass = storageClass
+		drift.addChange("storageClass", cloudBucket.Status.BucketExists && strings.EqualFold(cloudBucket.Status.StorageClass, storageClass))
+	}
+
+	// Autoclass is only managed when set in the spec
+	if autoclass := cloudBucket.Spec.Autoclass; autoclass != nil && !autoclassMatches(autoclass, bucket.Autoclass) {
+		drift.update.Autoclass = toProviderAutoclass(autoclass)
+		drift.addChange("autoclass", cloudBucket.Status.BucketExists && autoclassMatches(autoclass, observedAutoclass(cloudBucket)))
+	}
+
+	// Versioning is only managed when set in the spec
+	if versioning := cloudBucket.Spec.Versioning; versioning != nil && versioning.Enabled != bucket.VersioningEnabled {
+		enabled := versioning.Enabled
+		drift.update.VersioningEnabled = &enabled
+		drift.addChange("versioning.enabled", cloudBucket.Status.BucketExists && cloudBucket.Status.VersioningEnabled == enabled)
+	}
+
+	// Lifecycle rules are only managed when set in the spec, and replaced as a whole. There is
+	// no record of the rules last applied, so a difference is drift if the spec was unchanged.
+	if cloudBucket.Spec.Lifecycle != nil {
+		lifecycle := toProviderLifecycle(cloudBucket.Spec.Lifecycle)
+		if !equality.Semantic.DeepEqual(lifecycle.Rules, bucket.Lifecycle.Rules) {
+			drift.update.Lifecycle = &lifecycle
+			drift.addChange("lifecycle", cloudBucket.Status.BucketExists && cloudBucket.Status.ObservedGeneration == cloudBucket.Generation)
+		}
+	}
+
+	// The retention policy is only managed when set in the spec. Locking is irreversible, so it
+	// needs the confirmation annotation, and a locked policy cannot be unlocked.
+	if policy := cloudBucket.Spec.RetentionPolicy; policy != nil {
+		var observed provider.RetentionPolicy
+		if bucket.RetentionPolicy != nil {
+			observed = *bucket.RetentionPolicy
+		}
+		if period := retentionPeriod(policy); period != observed.Period {
+			drift.update.RetentionPeriod = &period
+			drift.addChange("retentionPolicy.period", cloudBucket.Status.BucketExists && observedRetentionPeriod(cloudBucket) == period)
+		}
+		switch {
+		case policy.Locked && !observed.IsLocked && cloudBucket.IsRetentionLockConfirmed():
+			drift.update.LockRetentionPolicy = true
+			drift.specChanges = append(drift.specChanges, "retentionPolicy.locked")
+		case !policy.Locked && observed.IsLocked:
+			drift.immutable = append(drift.immutable, "retentionPolicy.locked")
+		}
+	}
+
+	// The soft delete policy is only managed when set in the spec; a bucket without one
+	// reported keeps deleted objects for the GCS default
+	if policy := cloudBucket.Spec.SoftDeletePolicy; policy != nil {
+		retention := softDeleteRetention(policy)
+		if bucket.SoftDeletePolicy == nil || bucket.SoftDeletePolicy.RetentionDuration != retention {
+			drift.update.SoftDeleteRetention = &retention
+			observed, ok := observedSoftDeleteRetention(cloudBucket)
+			drift.addChange("softDeletePolicy.retentionDuration", cloudBucket.Status.BucketExists && ok && observed == retention)
+		}
+	}
+
+	// Location cannot be changed once the bucket exists; GCS reports it upper-cased.
+	if cloudBucket.Spec.Location != "" && !strings.EqualFold(cloudBucket.Spec.Location, bucket.Location) {
+		drift.immutable = append(drift.immutable, "location")
+	}
+
+	return drift
+}
+
+// diffLabels compares the labels observed on a bucket with the desired labels.
+// It returns the labels to set because they are missing or have drifted, and the
+// keys to delete because they were previously applied by the controller but are
+// no longer desired. Labels added to the bucket by others are left untouched.
+func diffLabels(observed, desired, previouslyApplied map[string]string) (map[string]string, []string) {
+	setLabels := make(map[string]string)
+	for k, v := range desired {
+		if current, ok := observed[k]; !ok || current != v {
+			setLabels[k] = v
+		}
+	}
+	var deleteLabels []string
+	for k := range previouslyApplied {
+		if _, wanted := desired[k]; wanted {
+			continue
+		}
+		if _, present := observed[k]; present {
+			deleteLabels = append(deleteLabels, k)
+		}
+	}
+	sort.Strings(deleteLabels)
+	return setLabels, deleteLabels
+}
+
+// updateOperation names the LastOperation recorded for a set of applied spec changes
+func updateOperation(fields []string) string {
+	for _, field := range fields {
+		if !strings.HasPrefix(field, "labels.") {
+			return "Updated"
+		}
+	}
+	return "LabelsUpdated"
+}
+
+// sortedKeys returns the keys of m in lexical order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+	"github.com/andreistefanciprian/cloud-storage-controller/internal/provider"
+)
+
+// Backoff for reconciles that failed with a transient error, per CloudBucket, on top of
+// an overall limit on how fast failed CloudBuckets are retried
+const (
+	transientBackoffBase  = time.Second
+	transientBackoffMax   = 5 * time.Minute
+	retryRateLimit        = 10
+	retryRateLimiterBurst = 100
+)
+
+// Operations for the ErrorsTotal operation label
+const (
+	operationFetch     = "fetch"
+	operationStatus    = "status"
+	operationFinalizer = "finalizer"
+	operationName      = "name"
+	operationGet       = "get"
+	operationCreate    = "create"
+	operationUpdate    = "update"
+	operationDelete    = "delete"
+	operationOwnership = "ownership"
+)
+
+// newRateLimiter returns the rate limiter that spaces out retries of failed reconciles
+func newRateLimiter() workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(transientBackoffBase, transientBackoffMax),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(retryRateLimit), retryRateLimiterBurst)},
+	)
+}
+
+// errorReason classifies an error from the Kubernetes API or the provider
+func errorReason(err error) string {
+	if reason := apierrors.ReasonForError(err); reason != metav1.StatusReasonUnknown {
+		return string(reason)
+	}
+	return string(provider.ReasonForError(err))
+}
+
+// countError records a failed operation in the ErrorsTotal metric
+func countError(operation string, err error) {
+	ErrorsTotal.WithLabelValues(operation, errorReason(err)).Inc()
+}
+
+// handleProviderError reports a failed bucket operation and schedules the retry.
+// Transient errors are returned so the controller retries them with exponential
+// backoff; permanent ones cannot be fixed by retrying and wait for the next resync.
+func (r *CloudBucketReconciler) handleProviderError(ctx context.Context, cloudBucket *mygroupv1.CloudBucket, operation, reason, action string, err error) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	errReason := provider.ReasonForError(err)
+	log.Error(err, "Failed to "+action, "reason", errReason, "transient", errReason.IsTransient())
+	markFailed(cloudBucket, reason, err)
+	// Ready explains why the bucket is unavailable, Synced which operation failed; during
+	// deletion Ready keeps reporting the deletion, as in markFailed
+	if cloudBucket.GetDeletionTimestamp() == nil {
+		setCondition(cloudBucket, mygroupv1.ConditionReady, metav1.ConditionFalse, string(errReason), err.Error())
+	}
+	ErrorsTotal.WithLabelValues(operation, string(errReason)).Inc()
+	r.EventRecorder.Event(cloudBucket, corev1.EventTypeWarning, "BucketFailed", fmt.Sprintf("Failed to %s: %v", action, err))
+	if errReason.IsTransient() {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.resyncPeriod(cloudBucket)}, nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+	"github.com/andreistefanciprian/cloud-storage-controller/internal/provider"
+)
+
+// toProviderLifecycle converts the lifecycle rules of a CloudBucket spec to provider rules
+func toProviderLifecycle(spec *mygroupv1.LifecycleSpec) provider.Lifecycle {
+	var lifecycle provider.Lifecycle
+	for _, rule := range spec.Rules {
+		condition := provider.LifecycleCondition{
+			AgeInDays:     rule.Condition.Age,
+			CreatedBefore: rule.Condition.CreatedBefore,
+			IsLive:        rule.Condition.IsLive,
+			MatchesPrefix: rule.Condition.MatchesPrefix,
+			MatchesSuffix: rule.Condition.MatchesSuffix,
+		}
+		if rule.Condition.NumNewerVersions != nil {
+			condition.NumNewerVersions = *rule.Condition.NumNewerVersions
+		}
+		if rule.Condition.DaysSinceNoncurrentTime != nil {
+			condition.DaysSinceNoncurrentTime = *rule.Condition.DaysSinceNoncurrentTime
+		}
+		for _, storageClass := range rule.Condition.MatchesStorageClass {
+			condition.MatchesStorageClass = append(condition.MatchesStorageClass, string(storageClass))
+		}
+		lifecycle.Rules = append(lifecycle.Rules, provider.LifecycleRule{
+			Action:       rule.Action.Type,
+			StorageClass: string(rule.Action.StorageClass),
+			Condition:    condition,
+		})
+	}
+	return lifecycle
+}
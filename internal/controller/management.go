@@ -0,0 +1,51 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+)
+
+// managementPolicy returns the management policy of the CloudBucket, defaulting to Full
+func managementPolicy(cloudBucket *mygroupv1.CloudBucket) string {
+	if cloudBucket.Spec.ManagementPolicy == "" {
+		return mygroupv1.ManagementPolicyFull
+	}
+	return cloudBucket.Spec.ManagementPolicy
+}
+
+// mayCreate reports whether the controller may create the bucket
+func mayCreate(cloudBucket *mygroupv1.CloudBucket) bool {
+	return managementPolicy(cloudBucket) != mygroupv1.ManagementPolicyObserveOnly
+}
+
+// mayUpdate reports whether the controller may change an existing bucket
+func mayUpdate(cloudBucket *mygroupv1.CloudBucket) bool {
+	return managementPolicy(cloudBucket) == mygroupv1.ManagementPolicyFull
+}
+
+// mayDelete reports whether the controller may delete the bucket
+func mayDelete(cloudBucket *mygroupv1.CloudBucket) bool {
+	return managementPolicy(cloudBucket) == mygroupv1.ManagementPolicyFull
+}
+
+// isBound reports whether the controller has created or adopted the bucket, as opposed to
+// only observing it. Labels are applied by both, so a bucket without applied labels was
+// never taken under management.
+func isBound(cloudBucket *mygroupv1.CloudBucket) bool {
+	return cloudBucket.Status.BucketExists && len(cloudBucket.Status.AppliedLabels) > 0
+}
@@ -40,12 +40,30 @@ var (
 		},
 	)
 
-	// ErrorsTotal counts the number of errors encountered
-	ErrorsTotal = prometheus.NewCounter(
+	// BucketsDriftCorrected counts the number of GCS buckets whose out-of-band changes were reverted
+	BucketsDriftCorrected = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "cloud_storage_buckets_drift_corrected_total",
+			Help: "Total number of GCS buckets whose drift from the spec was corrected",
+		},
+	)
+
+	// ObjectsPurged counts the number of object versions deleted to empty buckets before deletion
+	ObjectsPurged = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "cloud_storage_objects_purged_total",
+			Help: "Total number of object versions deleted while purging buckets",
+		},
+	)
+
+	// ErrorsTotal counts the number of errors encountered, by the operation that failed
+	// and the reason it failed, e.g. {operation="create", reason="PermissionDenied"}
+	ErrorsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "cloud_storage_errors_total",
 			Help: "Total number of errors during bucket operations",
 		},
+		[]string{"operation", "reason"},
 	)
 )
 
@@ -57,6 +75,8 @@ func init() {
 		BucketsRecreated,
 		BucketsDeleted,
 		BucketsOrphaned,
+		BucketsDriftCorrected,
+		ObjectsPurged,
 		ErrorsTotal,
 	)
 }
@@ -0,0 +1,164 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+)
+
+const (
+	// minBucketNameLength and maxBucketNameLength bound GCS bucket names without dots
+	minBucketNameLength = 3
+	maxBucketNameLength = 63
+	// maxDottedBucketNameLength bounds GCS bucket names containing dots
+	maxDottedBucketNameLength = 222
+	// suffixLength is the length of the hash or random suffix appended to a prefix
+	suffixLength = 8
+	// maxNameAttempts bounds how often a name taken by another project is regenerated
+	maxNameAttempts = 5
+)
+
+// namingStrategy returns the effective naming strategy of a CloudBucket
+func namingStrategy(cloudBucket *mygroupv1.CloudBucket) string {
+	if cloudBucket.Spec.NamingStrategy != "" {
+		return cloudBucket.Spec.NamingStrategy
+	}
+	if cloudBucket.Spec.BucketName != "" {
+		return mygroupv1.NamingStrategyExact
+	}
+	return mygroupv1.NamingStrategyHash
+}
+
+// generateBucketName derives the GCS bucket name of a CloudBucket from its naming strategy
+// and validates it. The Hash strategy always yields the same name for the same object and
+// number of name attempts.
+func generateBucketName(cloudBucket *mygroupv1.CloudBucket) (string, error) {
+	prefix := cloudBucket.Spec.BucketName
+	if prefix == "" {
+		prefix = cloudBucket.Name
+	}
+
+	var name string
+	switch strategy := namingStrategy(cloudBucket); strategy {
+	case mygroupv1.NamingStrategyExact:
+		name = cloudBucket.Spec.BucketName
+	case mygroupv1.NamingStrategyHash:
+		seed := cloudBucket.Namespace + "/" + cloudBucket.Name + "/" + string(cloudBucket.UID)
+		if cloudBucket.Status.NameAttempts > 0 {
+			seed = fmt.Sprintf("%s/%d", seed, cloudBucket.Status.NameAttempts)
+		}
+		sum := sha256.Sum256([]byte(seed))
+		name = joinBucketName(prefix, hex.EncodeToString(sum[:])[:suffixLength])
+	case mygroupv1.NamingStrategyRandom:
+		suffix, err := randomSuffix()
+		if err != nil {
+			return "", err
+		}
+		name = joinBucketName(prefix, suffix)
+	default:
+		return "", fmt.Errorf("unknown naming strategy %q", strategy)
+	}
+
+	if err := validateBucketName(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// joinBucketName sanitizes the prefix and appends the suffix, keeping the result within 63 characters
+func joinBucketName(prefix, suffix string) string {
+	prefix = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, prefix)
+	if maxPrefix := maxBucketNameLength - len(suffix) - 1; len(prefix) > maxPrefix {
+		prefix = prefix[:maxPrefix]
+	}
+	prefix = strings.Trim(prefix, "-")
+	if prefix == "" {
+		return suffix
+	}
+	return prefix + "-" + suffix
+}
+
+// randomSuffix returns a random string of lowercase letters and digits
+func randomSuffix() (string, error) {
+	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
+	suffix := make([]byte, suffixLength)
+	for i := range suffix {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		if err != nil {
+			return "", fmt.Errorf("generating random suffix: %w", err)
+		}
+		suffix[i] = charset[n.Int64()]
+	}
+	return string(suffix), nil
+}
+
+// validateBucketName checks a name against the GCS bucket naming requirements
+// (https://cloud.google.com/storage/docs/buckets#naming)
+func validateBucketName(name string) error {
+	maxLength := maxBucketNameLength
+	if strings.Contains(name, ".") {
+		maxLength = maxDottedBucketNameLength
+	}
+	if len(name) < minBucketNameLength || len(name) > maxLength {
+		return fmt.Errorf("bucket name %q must be between %d and %d characters", name, minBucketNameLength, maxLength)
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return fmt.Errorf("bucket name %q may only contain lowercase letters, digits, dashes, underscores and dots", name)
+		}
+	}
+	if !isAlphanumeric(name[0]) || !isAlphanumeric(name[len(name)-1]) {
+		return fmt.Errorf("bucket name %q must start and end with a letter or digit", name)
+	}
+	for _, component := range strings.Split(name, ".") {
+		if len(component) == 0 || len(component) > maxBucketNameLength {
+			return fmt.Errorf("bucket name %q must have dot-separated components of 1 to %d characters", name, maxBucketNameLength)
+		}
+	}
+	if net.ParseIP(name) != nil {
+		return fmt.Errorf("bucket name %q cannot be an IP address", name)
+	}
+	if strings.HasPrefix(name, "goog") {
+		return fmt.Errorf("bucket name %q cannot begin with the \"goog\" prefix", name)
+	}
+	if strings.Contains(name, "google") || strings.Contains(name, "g00gle") {
+		return fmt.Errorf("bucket name %q cannot contain \"google\" or close misspellings", name)
+	}
+	return nil
+}
+
+// isAlphanumeric reports whether c is a lowercase letter or a digit
+func isAlphanumeric(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+}
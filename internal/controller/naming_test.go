@@ -0,0 +1,106 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+)
+
+var _ = Describe("Bucket naming", func() {
+	newCloudBucket := func(name, bucketName, strategy string) *mygroupv1.CloudBucket {
+		return &mygroupv1.CloudBucket{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: "1b4e28ba-2fa1-11d2-883f-0016d3cca427"},
+			Spec: mygroupv1.CloudBucketSpec{
+				ProjectID:      "test-project",
+				BucketName:     bucketName,
+				NamingStrategy: strategy,
+			},
+		}
+	}
+
+	It("should use spec.bucketName as-is with the Exact strategy", func() {
+		name, err := generateBucketName(newCloudBucket("photos", "acme-photos", ""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("acme-photos"))
+	})
+
+	It("should derive a stable name from namespace, name and UID with the Hash strategy", func() {
+		first, err := generateBucketName(newCloudBucket("photos", "", ""))
+		Expect(err).NotTo(HaveOccurred())
+		second, err := generateBucketName(newCloudBucket("photos", "", mygroupv1.NamingStrategyHash))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).To(Equal(second))
+		Expect(first).To(MatchRegexp(`^photos-[0-9a-f]{8}$`))
+
+		other := newCloudBucket("photos", "", "")
+		other.UID = "6fa459ea-ee8a-3ca4-894e-db77e160355e"
+		third, err := generateBucketName(other)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(third).NotTo(Equal(first))
+	})
+
+	It("should append a random suffix with the Random strategy", func() {
+		first, err := generateBucketName(newCloudBucket("photos", "acme", mygroupv1.NamingStrategyRandom))
+		Expect(err).NotTo(HaveOccurred())
+		second, err := generateBucketName(newCloudBucket("photos", "acme", mygroupv1.NamingStrategyRandom))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).To(MatchRegexp(`^acme-[a-z0-9]{8}$`))
+		Expect(first).NotTo(Equal(second))
+	})
+
+	It("should sanitize and truncate generated names to valid GCS names", func() {
+		name, err := generateBucketName(newCloudBucket("My.Photos_"+strings.Repeat("x", 80), "", ""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(len(name)).To(BeNumerically("<=", 63))
+		Expect(name).To(HavePrefix("my-photos-xxx"))
+		Expect(validateBucketName(name)).To(Succeed())
+	})
+
+	It("should reject an invalid exact name", func() {
+		_, err := generateBucketName(newCloudBucket("photos", "google-photos", ""))
+		Expect(err).To(MatchError(ContainSubstring("google")))
+	})
+
+	DescribeTable("validating GCS bucket names",
+		func(name string, valid bool) {
+			if valid {
+				Expect(validateBucketName(name)).To(Succeed())
+			} else {
+				Expect(validateBucketName(name)).NotTo(Succeed())
+			}
+		},
+		Entry("simple name", "my-bucket", true),
+		Entry("underscores and digits", "my_bucket_01", true),
+		Entry("dotted name", "assets.example.com", true),
+		Entry("too short", "ab", false),
+		Entry("too long", strings.Repeat("a", 64), false),
+		Entry("uppercase", "My-Bucket", false),
+		Entry("leading dash", "-bucket", false),
+		Entry("trailing underscore", "bucket_", false),
+		Entry("empty dot component", "my..bucket", false),
+		Entry("IP address", "192.168.5.4", false),
+		Entry("goog prefix", "googbucket", false),
+		Entry("contains google", "my-google-bucket", false),
+		Entry("contains misspelled google", "my-g00gle-bucket", false),
+	)
+})
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+	"github.com/andreistefanciprian/cloud-storage-controller/internal/provider"
+)
+
+// Labels identifying the CloudBucket, and the cluster it lives in, that owns a bucket
+const (
+	ownerClusterLabel   = "cloudbucket-cluster"
+	ownerNamespaceLabel = "cloudbucket-namespace"
+	ownerNameLabel      = "cloudbucket-name"
+	ownerUIDLabel       = "cloudbucket-uid"
+)
+
+// maxLabelValueLength is the maximum length of a GCS label value
+const maxLabelValueLength = 63
+
+// ownerLabels returns the labels that tie a bucket to the CloudBucket in the given cluster.
+// The namespace and name are informational; the UID hash identifies the owner.
+func ownerLabels(clusterID string, cloudBucket *mygroupv1.CloudBucket) map[string]string {
+	labels := map[string]string{
+		ownerNamespaceLabel: labelValue(cloudBucket.Namespace),
+		ownerNameLabel:      labelValue(cloudBucket.Name),
+		ownerUIDLabel:       uidHash(cloudBucket),
+	}
+	if clusterID != "" {
+		labels[ownerClusterLabel] = labelValue(clusterID)
+	}
+	return labels
+}
+
+// uidHash returns a short, stable hash of the CloudBucket UID
+func uidHash(cloudBucket *mygroupv1.CloudBucket) string {
+	sum := sha256.Sum256([]byte(cloudBucket.UID))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// labelValue converts s into a valid GCS label value: lowercase letters, digits,
+// underscores and dashes, at most 63 characters
+func labelValue(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	value := b.String()
+	if len(value) > maxLabelValueLength {
+		value = value[:maxLabelValueLength]
+	}
+	return value
+}
+
+// checkOwnership returns an error if the bucket's owner labels name another CloudBucket
+// or cluster. Buckets without owner labels, such as ones created before the labels were
+// introduced, are not in conflict and get labelled on the next update.
+func checkOwnership(clusterID string, cloudBucket *mygroupv1.CloudBucket, bucket *provider.Bucket) error {
+	want := ownerLabels(clusterID, cloudBucket)
+	if cluster, ok := bucket.Labels[ownerClusterLabel]; ok && clusterID != "" && cluster != want[ownerClusterLabel] {
+		return fmt.Errorf("bucket %s is owned by cluster %s", bucket.Name, cluster)
+	}
+	if uid, ok := bucket.Labels[ownerUIDLabel]; ok && uid != want[ownerUIDLabel] {
+		return fmt.Errorf("bucket %s is owned by CloudBucket %s/%s", bucket.Name,
+			bucket.Labels[ownerNamespaceLabel], bucket.Labels[ownerNameLabel])
+	}
+	return nil
+}
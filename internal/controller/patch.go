@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+)
+
+// patchStatus writes the status of the CloudBucket with a merge patch against the status
+// it was read with, so only the fields the reconcile changed are sent. The patch is guarded
+// by the resourceVersion, so a status computed from a stale object, e.g. a second generated
+// bucket name, fails with a conflict instead of overwriting a newer one. Nothing is written
+// when the status is unchanged, or when the CloudBucket is gone after its finalizer was removed.
+func (r *CloudBucketReconciler) patchStatus(ctx context.Context, original, cloudBucket *mygroupv1.CloudBucket) error {
+	if equality.Semantic.DeepEqual(original.Status, cloudBucket.Status) {
+		return nil
+	}
+	// Diff the status only; metadata changed by finalizer patches must not be sent again
+	base := cloudBucket.DeepCopy()
+	base.Status = original.Status
+	return client.IgnoreNotFound(r.Status().Patch(ctx, cloudBucket, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})))
+}
+
+// patchFinalizers applies mutate to the finalizers of the CloudBucket and saves them with a
+// merge patch guarded by the resourceVersion, so finalizers set by others are never lost.
+// Conflicts are retried against the latest object. Only the finalizers and resourceVersion
+// of cloudBucket are refreshed, keeping the status computed so far.
+func (r *CloudBucketReconciler) patchFinalizers(ctx context.Context, cloudBucket *mygroupv1.CloudBucket, mutate func(client.Object) bool) error {
+	latest := cloudBucket.DeepCopy()
+	refresh := false
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if refresh {
+			if err := r.Get(ctx, client.ObjectKeyFromObject(cloudBucket), latest); err != nil {
+				return err
+			}
+		}
+		refresh = true
+		base := latest.DeepCopy()
+		if !mutate(latest) {
+			return nil
+		}
+		return r.Patch(ctx, latest, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}))
+	})
+	if err != nil {
+		return err
+	}
+	cloudBucket.Finalizers = latest.Finalizers
+	cloudBucket.ResourceVersion = latest.ResourceVersion
+	return nil
+}
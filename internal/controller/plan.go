@@ -0,0 +1,189 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+	"github.com/andreistefanciprian/cloud-storage-controller/internal/provider"
+)
+
+// isDryRun reports whether changes to the CloudBucket's bucket should only be planned
+func (r *CloudBucketReconciler) isDryRun(cloudBucket *mygroupv1.CloudBucket) bool {
+	return r.DryRun || cloudBucket.IsDryRun()
+}
+
+// planCreate plans the creation of a bucket
+func planCreate(projectID string, bucket *provider.Bucket) []mygroupv1.PlannedAction {
+	description := fmt.Sprintf("create bucket %s in project %s", bucket.Name, projectID)
+	if bucket.Location != "" {
+		description += " at " + bucket.Location
+	}
+	pairs := make([]string, 0, len(bucket.Labels))
+	for _, k := range sortedKeys(bucket.Labels) {
+		pairs = append(pairs, k+"="+bucket.Labels[k])
+	}
+	description += " with labels " + strings.Join(pairs, ",")
+	if bucket.StorageClass != "" {
+		description += fmt.Sprintf(", storage class %s", bucket.StorageClass)
+	}
+	if bucket.Autoclass != nil {
+		description += ", Autoclass enabled"
+	}
+	if bucket.VersioningEnabled {
+		description += ", versioning enabled"
+	}
+	if n := len(bucket.Lifecycle.Rules); n > 0 {
+		description += fmt.Sprintf(", %d lifecycle rules", n)
+	}
+	if bucket.RetentionPolicy != nil {
+		description += fmt.Sprintf(", retention period %s", bucket.RetentionPolicy.Period)
+	}
+	if bucket.SoftDeletePolicy != nil {
+		description += fmt.Sprintf(", soft delete retention %s", bucket.SoftDeletePolicy.RetentionDuration)
+	}
+	return []mygroupv1.PlannedAction{{Action: mygroupv1.PlannedActionCreate, Description: description}}
+}
+
+// planUpdate plans the changes of a bucket update, one action per field
+func planUpdate(update provider.BucketUpdate) []mygroupv1.PlannedAction {
+	var plan []mygroupv1.PlannedAction
+	for _, k := range sortedKeys(update.SetLabels) {
+		plan = append(plan, mygroupv1.PlannedAction{
+			Action:      mygroupv1.PlannedActionUpdate,
+			Field:       "labels." + k,
+			Description: fmt.Sprintf("set to %q", update.SetLabels[k]),
+		})
+	}
+	for _, k := range update.DeleteLabels {
+		plan = append(plan, mygroupv1.PlannedAction{
+			Action:      mygroupv1.PlannedActionUpdate,
+			Field:       "labels." + k,
+			Description: "remove",
+		})
+	}
+	if update.StorageClass != "" {
+		plan = append(plan, mygroupv1.PlannedAction{
+			Action:      mygroupv1.PlannedActionUpdate,
+			Field:       "storageClass",
+			Description: "set to " + update.StorageClass,
+		})
+	}
+	if update.Autoclass != nil {
+		description := "disable"
+		if update.Autoclass.Enabled {
+			description = "enable"
+			if update.Autoclass.TerminalStorageClass != "" {
+				description += " with terminal storage class " + update.Autoclass.TerminalStorageClass
+			}
+		}
+		plan = append(plan, mygroupv1.PlannedAction{
+			Action:      mygroupv1.PlannedActionUpdate,
+			Field:       "autoclass",
+			Description: description,
+		})
+	}
+	if update.VersioningEnabled != nil {
+		plan = append(plan, mygroupv1.PlannedAction{
+			Action:      mygroupv1.PlannedActionUpdate,
+			Field:       "versioning.enabled",
+			Description: fmt.Sprintf("set to %t", *update.VersioningEnabled),
+		})
+	}
+	if update.Lifecycle != nil {
+		description := fmt.Sprintf("replace with %d rules", len(update.Lifecycle.Rules))
+		if len(update.Lifecycle.Rules) == 0 {
+			description = "remove all rules"
+		}
+		plan = append(plan, mygroupv1.PlannedAction{
+			Action:      mygroupv1.PlannedActionUpdate,
+			Field:       "lifecycle",
+			Description: description,
+		})
+	}
+	if update.RetentionPeriod != nil {
+		description := fmt.Sprintf("set to %s", *update.RetentionPeriod)
+		if *update.RetentionPeriod == 0 {
+			description = "remove the retention policy"
+		}
+		plan = append(plan, mygroupv1.PlannedAction{
+			Action:      mygroupv1.PlannedActionUpdate,
+			Field:       "retentionPolicy.period",
+			Description: description,
+		})
+	}
+	if update.SoftDeleteRetention != nil {
+		description := fmt.Sprintf("set to %s", *update.SoftDeleteRetention)
+		if *update.SoftDeleteRetention == 0 {
+			description = "disable soft delete"
+		}
+		plan = append(plan, mygroupv1.PlannedAction{
+			Action:      mygroupv1.PlannedActionUpdate,
+			Field:       "softDeletePolicy.retentionDuration",
+			Description: description,
+		})
+	}
+	if update.LockRetentionPolicy {
+		plan = append(plan, mygroupv1.PlannedAction{
+			Action:      mygroupv1.PlannedActionUpdate,
+			Field:       "retentionPolicy.locked",
+			Description: "lock the retention policy, irreversibly",
+		})
+	}
+	return plan
+}
+
+// planDelete plans the deletion of a bucket, and of its contents with DeleteWithContents
+func planDelete(cloudBucket *mygroupv1.CloudBucket) []mygroupv1.PlannedAction {
+	var plan []mygroupv1.PlannedAction
+	if cloudBucket.Spec.DeletePolicy == mygroupv1.DeletePolicyDeleteWithContents {
+		plan = append(plan, mygroupv1.PlannedAction{
+			Action:      mygroupv1.PlannedActionDeleteContents,
+			Description: fmt.Sprintf("delete every object and object version in bucket %s", cloudBucket.Status.BucketName),
+		})
+	}
+	return append(plan, mygroupv1.PlannedAction{
+		Action:      mygroupv1.PlannedActionDelete,
+		Description: fmt.Sprintf("delete bucket %s", cloudBucket.Status.BucketName),
+	})
+}
+
+// planSummary renders a plan on a single line for events and conditions
+func planSummary(plan []mygroupv1.PlannedAction) string {
+	steps := make([]string, 0, len(plan))
+	for _, action := range plan {
+		step := action.Action
+		if action.Field != "" {
+			step += " " + action.Field
+		}
+		steps = append(steps, step+": "+action.Description)
+	}
+	return strings.Join(steps, "; ")
+}
+
+// recordPlan stores the plan in status and emits an event when it changed
+func (r *CloudBucketReconciler) recordPlan(cloudBucket *mygroupv1.CloudBucket, plan []mygroupv1.PlannedAction) {
+	if len(plan) > 0 && !equality.Semantic.DeepEqual(plan, cloudBucket.Status.Plan) {
+		r.EventRecorder.Event(cloudBucket, corev1.EventTypeNormal, "DryRun", "Planned: "+planSummary(plan))
+	}
+	cloudBucket.Status.Plan = plan
+}
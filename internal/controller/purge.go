@@ -0,0 +1,102 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+	"github.com/andreistefanciprian/cloud-storage-controller/internal/provider"
+)
+
+const (
+	// purgeBatchSize is the number of objects listed and deleted per batch
+	purgeBatchSize = 1000
+	// purgeParallelism bounds the number of concurrent object deletions
+	purgeParallelism = 16
+	// purgeTimeBudget bounds how long a single reconcile spends deleting objects, so
+	// progress is written to status regularly and other CloudBuckets are not kept
+	// waiting for a worker
+	purgeTimeBudget = 5 * time.Second
+	// purgeRequeueDelay is how soon an unfinished purge continues
+	purgeRequeueDelay = time.Second
+)
+
+// deletesBucket reports whether the delete policy removes the bucket
+func deletesBucket(cloudBucket *mygroupv1.CloudBucket) bool {
+	policy := cloudBucket.Spec.DeletePolicy
+	return policy == mygroupv1.DeletePolicyDelete || policy == mygroupv1.DeletePolicyDeleteWithContents
+}
+
+// purgeBucket deletes the objects of the CloudBucket's bucket in parallel batches until
+// the bucket is empty or the time budget runs out, recording progress in status. Every
+// batch lists from the first remaining object, so a purge interrupted by a failure or a
+// controller restart simply resumes. It reports whether the bucket is empty.
+func (r *CloudBucketReconciler) purgeBucket(ctx context.Context, cloudBucket *mygroupv1.CloudBucket) (bool, error) {
+	bucketName := cloudBucket.Status.BucketName
+	if cloudBucket.Status.Purge == nil {
+		cloudBucket.Status.Purge = &mygroupv1.PurgeStatus{StartTime: metav1.Now()}
+	}
+	progress := cloudBucket.Status.Purge
+
+	deadline := time.Now().Add(purgeTimeBudget)
+	for time.Now().Before(deadline) {
+		objects, err := r.Provider.ListObjects(ctx, bucketName, purgeBatchSize)
+		if provider.IsNotFound(err) {
+			progress.Completed = true
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if len(objects) == 0 {
+			progress.Completed = true
+			return true, nil
+		}
+		deleted, err := r.deleteObjects(ctx, bucketName, objects)
+		progress.ObjectsDeleted += deleted
+		ObjectsPurged.Add(float64(deleted))
+		if err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// deleteObjects deletes a batch of objects concurrently and returns how many were deleted
+func (r *CloudBucketReconciler) deleteObjects(ctx context.Context, bucketName string, objects []provider.Object) (int64, error) {
+	var deleted atomic.Int64
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(purgeParallelism)
+	for _, object := range objects {
+		object := object
+		g.Go(func() error {
+			if err := r.Provider.DeleteObject(ctx, bucketName, object); err != nil {
+				return err
+			}
+			deleted.Add(1)
+			return nil
+		})
+	}
+	err := g.Wait()
+	return deleted.Load(), err
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+	"github.com/andreistefanciprian/cloud-storage-controller/internal/provider"
+)
+
+// retentionPeriod returns the desired retention period, in the whole seconds GCS stores
+func retentionPeriod(policy *mygroupv1.RetentionPolicySpec) time.Duration {
+	return policy.Period.Duration.Truncate(time.Second)
+}
+
+// retentionLockUnconfirmed reports whether the spec asks to lock the retention policy of an
+// unlocked bucket without the confirmation annotation
+func retentionLockUnconfirmed(cloudBucket *mygroupv1.CloudBucket, bucket *provider.Bucket) bool {
+	policy := cloudBucket.Spec.RetentionPolicy
+	if policy == nil || !policy.Locked || cloudBucket.IsRetentionLockConfirmed() {
+		return false
+	}
+	return bucket.RetentionPolicy == nil || !bucket.RetentionPolicy.IsLocked
+}
+
+// retentionPolicyStatus converts a retention policy observed on a bucket for reporting in status
+func retentionPolicyStatus(policy *provider.RetentionPolicy) *mygroupv1.RetentionPolicyStatus {
+	if policy == nil {
+		return nil
+	}
+	status := &mygroupv1.RetentionPolicyStatus{
+		Period:   metav1.Duration{Duration: policy.Period},
+		IsLocked: policy.IsLocked,
+	}
+	if !policy.EffectiveTime.IsZero() {
+		effectiveTime := metav1.NewTime(policy.EffectiveTime)
+		status.EffectiveTime = &effectiveTime
+	}
+	return status
+}
+
+// observedRetentionPeriod returns the retention period last reported in status, 0 if none
+func observedRetentionPeriod(cloudBucket *mygroupv1.CloudBucket) time.Duration {
+	if cloudBucket.Status.RetentionPolicy == nil {
+		return 0
+	}
+	return cloudBucket.Status.RetentionPolicy.Period.Duration
+}
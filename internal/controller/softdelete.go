@@ -0,0 +1,55 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+	"github.com/andreistefanciprian/cloud-storage-controller/internal/provider"
+)
+
+// softDeleteRetention returns the desired soft delete retention, in the whole seconds GCS stores
+func softDeleteRetention(policy *mygroupv1.SoftDeletePolicySpec) time.Duration {
+	return policy.RetentionDuration.Duration.Truncate(time.Second)
+}
+
+// softDeletePolicyStatus converts a soft delete policy observed on a bucket for reporting in status
+func softDeletePolicyStatus(policy *provider.SoftDeletePolicy) *mygroupv1.SoftDeletePolicyStatus {
+	if policy == nil {
+		return nil
+	}
+	status := &mygroupv1.SoftDeletePolicyStatus{
+		RetentionDuration: metav1.Duration{Duration: policy.RetentionDuration},
+	}
+	if !policy.EffectiveTime.IsZero() {
+		effectiveTime := metav1.NewTime(policy.EffectiveTime)
+		status.EffectiveTime = &effectiveTime
+	}
+	return status
+}
+
+// observedSoftDeleteRetention returns the soft delete retention last reported in status, and
+// whether one was reported
+func observedSoftDeleteRetention(cloudBucket *mygroupv1.CloudBucket) (time.Duration, bool) {
+	if cloudBucket.Status.SoftDeletePolicy == nil {
+		return 0, false
+	}
+	return cloudBucket.Status.SoftDeletePolicy.RetentionDuration.Duration, true
+}
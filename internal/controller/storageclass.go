@@ -0,0 +1,67 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	mygroupv1 "github.com/andreistefanciprian/cloud-storage-controller/api/v1"
+	"github.com/andreistefanciprian/cloud-storage-controller/internal/provider"
+)
+
+// autoclassEnabled reports whether the spec enables Autoclass
+func autoclassEnabled(cloudBucket *mygroupv1.CloudBucket) bool {
+	return cloudBucket.Spec.Autoclass != nil && cloudBucket.Spec.Autoclass.Enabled
+}
+
+// toProviderAutoclass converts the Autoclass spec of a CloudBucket to the provider configuration
+func toProviderAutoclass(spec *mygroupv1.AutoclassSpec) *provider.Autoclass {
+	return &provider.Autoclass{
+		Enabled:              spec.Enabled,
+		TerminalStorageClass: string(spec.TerminalStorageClass),
+	}
+}
+
+// autoclassMatches reports whether an Autoclass configuration, nil when disabled, satisfies the
+// spec. A terminal storage class left to the GCS default matches any.
+func autoclassMatches(spec *mygroupv1.AutoclassSpec, autoclass *provider.Autoclass) bool {
+	if !spec.Enabled {
+		return autoclass == nil
+	}
+	return autoclass != nil &&
+		(spec.TerminalStorageClass == "" || strings.EqualFold(string(spec.TerminalStorageClass), autoclass.TerminalStorageClass))
+}
+
+// autoclassStatus converts an Autoclass configuration observed on a bucket for reporting in status
+func autoclassStatus(autoclass *provider.Autoclass) *mygroupv1.AutoclassStatus {
+	if autoclass == nil {
+		return nil
+	}
+	return &mygroupv1.AutoclassStatus{
+		Enabled:              autoclass.Enabled,
+		TerminalStorageClass: autoclass.TerminalStorageClass,
+	}
+}
+
+// observedAutoclass returns the Autoclass configuration last reported in status
+func observedAutoclass(cloudBucket *mygroupv1.CloudBucket) *provider.Autoclass {
+	status := cloudBucket.Status.Autoclass
+	if status == nil || !status.Enabled {
+		return nil
+	}
+	return &provider.Autoclass{Enabled: true, TerminalStorageClass: status.TerminalStorageClass}
+}
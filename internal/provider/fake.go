@@ -0,0 +1,398 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Operation identifies a BucketProvider method, for failure injection and call counting.
+type Operation string
+
+const (
+	OperationCreate Operation = "Create"
+	OperationGet    Operation = "Get"
+	OperationUpdate Operation = "Update"
+	OperationDelete Operation = "Delete"
+	OperationList   Operation = "List"
+
+	OperationListObjects  Operation = "ListObjects"
+	OperationDeleteObject Operation = "DeleteObject"
+)
+
+// FakeProvider is an in-memory BucketProvider intended for tests.
+// It supports failure injection per operation, artificial latency and
+// simulating buckets that are deleted outside of the controller.
+type FakeProvider struct {
+	mu       sync.Mutex
+	buckets  map[string]*fakeBucket
+	failures map[Operation]error
+	calls    map[Operation]int
+	latency  time.Duration
+}
+
+// fakeBucket is a bucket stored by FakeProvider along with its owning project.
+// Foreign buckets belong to a project the caller cannot access.
+type fakeBucket struct {
+	projectID string
+	foreign   bool
+	bucket    Bucket
+	objects   []Object
+}
+
+var _ BucketProvider = &FakeProvider{}
+
+// NewFakeProvider returns an empty FakeProvider.
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{
+		buckets:  make(map[string]*fakeBucket),
+		failures: make(map[Operation]error),
+		calls:    make(map[Operation]int),
+	}
+}
+
+// FailOn makes every subsequent call to op return err until ClearFailures is called.
+func (f *FakeProvider) FailOn(op Operation, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[op] = err
+}
+
+// ClearFailures removes all injected failures.
+func (f *FakeProvider) ClearFailures() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = make(map[Operation]error)
+}
+
+// SetLatency delays every call by d, honouring context cancellation.
+func (f *FakeProvider) SetLatency(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.latency = d
+}
+
+// AddBucket stores a bucket in the given project as if it had been created out-of-band.
+func (f *FakeProvider) AddBucket(projectID string, bucket *Bucket) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.buckets[bucket.Name] = &fakeBucket{projectID: projectID, bucket: copyBucket(bucket)}
+}
+
+// AddForeignBucket reserves a bucket name as if it were owned by another project:
+// Get, Update and Delete are denied, Create reports a conflict and List omits it.
+func (f *FakeProvider) AddForeignBucket(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.buckets[name] = &fakeBucket{foreign: true, bucket: Bucket{Name: name}}
+}
+
+// AddObjects stores objects in an existing bucket.
+func (f *FakeProvider) AddObjects(bucket string, objects ...Object) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if stored, ok := f.buckets[bucket]; ok {
+		stored.objects = append(stored.objects, objects...)
+	}
+}
+
+// Objects returns the objects left in a bucket.
+func (f *FakeProvider) Objects(bucket string) []Object {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored, ok := f.buckets[bucket]
+	if !ok {
+		return nil
+	}
+	return append([]Object(nil), stored.objects...)
+}
+
+// DeleteOutOfBand removes a bucket as if it had been deleted outside of the controller.
+func (f *FakeProvider) DeleteOutOfBand(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.buckets, name)
+}
+
+// Bucket returns a copy of the stored bucket, if present.
+func (f *FakeProvider) Bucket(name string) (*Bucket, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored, ok := f.buckets[name]
+	if !ok {
+		return nil, false
+	}
+	bucket := copyBucket(&stored.bucket)
+	return &bucket, true
+}
+
+// Calls returns the number of times op has been invoked.
+func (f *FakeProvider) Calls(op Operation) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[op]
+}
+
+// Create stores a new bucket
+func (f *FakeProvider) Create(ctx context.Context, projectID string, bucket *Bucket) error {
+	if err := f.begin(ctx, OperationCreate); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.buckets[bucket.Name]; ok {
+		return fmt.Errorf("Bucket(%q).Create: %w", bucket.Name, ErrBucketAlreadyExists)
+	}
+	stored := &fakeBucket{projectID: projectID, bucket: copyBucket(bucket)}
+	if policy := stored.bucket.RetentionPolicy; policy != nil {
+		policy.EffectiveTime = time.Now()
+		policy.IsLocked = false
+	}
+	if policy := stored.bucket.SoftDeletePolicy; policy != nil {
+		policy.EffectiveTime = time.Now()
+	}
+	if stored.bucket.StorageClass == "" {
+		stored.bucket.StorageClass = "STANDARD"
+	}
+	if stored.bucket.Autoclass != nil {
+		stored.bucket.Autoclass = newFakeAutoclass(stored.bucket.Autoclass)
+	}
+	f.buckets[bucket.Name] = stored
+	return nil
+}
+
+// Get returns a stored bucket
+func (f *FakeProvider) Get(ctx context.Context, name string) (*Bucket, error) {
+	if err := f.begin(ctx, OperationGet); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored, ok := f.buckets[name]
+	if !ok {
+		return nil, fmt.Errorf("Bucket(%q).Attrs: %w", name, ErrBucketNotFound)
+	}
+	if stored.foreign {
+		return nil, fmt.Errorf("Bucket(%q).Attrs: %w", name, ErrAccessDenied)
+	}
+	bucket := copyBucket(&stored.bucket)
+	return &bucket, nil
+}
+
+// Update applies changes to a stored bucket
+func (f *FakeProvider) Update(ctx context.Context, name string, update BucketUpdate) (*Bucket, error) {
+	if err := f.begin(ctx, OperationUpdate); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored, ok := f.buckets[name]
+	if !ok {
+		return nil, fmt.Errorf("Bucket(%q).Update: %w", name, ErrBucketNotFound)
+	}
+	if stored.foreign {
+		return nil, fmt.Errorf("Bucket(%q).Update: %w", name, ErrAccessDenied)
+	}
+	if err := updateRetentionPolicy(&stored.bucket, update); err != nil {
+		return nil, fmt.Errorf("Bucket(%q).Update: %w", name, err)
+	}
+	if stored.bucket.Labels == nil {
+		stored.bucket.Labels = make(map[string]string)
+	}
+	for k, v := range update.SetLabels {
+		stored.bucket.Labels[k] = v
+	}
+	for _, k := range update.DeleteLabels {
+		delete(stored.bucket.Labels, k)
+	}
+	if update.StorageClass != "" {
+		stored.bucket.StorageClass = update.StorageClass
+	}
+	if update.Autoclass != nil {
+		stored.bucket.Autoclass = newFakeAutoclass(update.Autoclass)
+	}
+	if update.VersioningEnabled != nil {
+		stored.bucket.VersioningEnabled = *update.VersioningEnabled
+	}
+	if update.Lifecycle != nil {
+		stored.bucket.Lifecycle = *update.Lifecycle
+	}
+	if update.SoftDeleteRetention != nil {
+		stored.bucket.SoftDeletePolicy = &SoftDeletePolicy{RetentionDuration: *update.SoftDeleteRetention, EffectiveTime: time.Now()}
+	}
+	bucket := copyBucket(&stored.bucket)
+	return &bucket, nil
+}
+
+// Delete removes a stored bucket
+func (f *FakeProvider) Delete(ctx context.Context, name string) error {
+	if err := f.begin(ctx, OperationDelete); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored, ok := f.buckets[name]
+	if !ok {
+		return fmt.Errorf("Bucket(%q).Delete: %w", name, ErrBucketNotFound)
+	}
+	if stored.foreign {
+		return fmt.Errorf("Bucket(%q).Delete: %w", name, ErrAccessDenied)
+	}
+	if len(stored.objects) > 0 {
+		return fmt.Errorf("Bucket(%q).Delete: %w", name, ErrBucketNotEmpty)
+	}
+	delete(f.buckets, name)
+	return nil
+}
+
+// ListObjects returns the first stored objects of a bucket
+func (f *FakeProvider) ListObjects(ctx context.Context, bucket string, limit int) ([]Object, error) {
+	if err := f.begin(ctx, OperationListObjects); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored, ok := f.buckets[bucket]
+	if !ok {
+		return nil, fmt.Errorf("Bucket(%q).Objects: %w", bucket, ErrBucketNotFound)
+	}
+	if stored.foreign {
+		return nil, fmt.Errorf("Bucket(%q).Objects: %w", bucket, ErrAccessDenied)
+	}
+	if len(stored.objects) < limit {
+		limit = len(stored.objects)
+	}
+	return append([]Object(nil), stored.objects[:limit]...), nil
+}
+
+// DeleteObject removes a stored object version
+func (f *FakeProvider) DeleteObject(ctx context.Context, bucket string, object Object) error {
+	if err := f.begin(ctx, OperationDeleteObject); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored, ok := f.buckets[bucket]
+	if !ok {
+		return fmt.Errorf("Bucket(%q).Objects: %w", bucket, ErrBucketNotFound)
+	}
+	for i, o := range stored.objects {
+		if o == object {
+			stored.objects = append(stored.objects[:i], stored.objects[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// List returns the stored buckets of a project that match the name prefix
+func (f *FakeProvider) List(ctx context.Context, projectID, prefix string) ([]*Bucket, error) {
+	if err := f.begin(ctx, OperationList); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var buckets []*Bucket
+	for name, stored := range f.buckets {
+		if !stored.foreign && stored.projectID == projectID && strings.HasPrefix(name, prefix) {
+			bucket := copyBucket(&stored.bucket)
+			buckets = append(buckets, &bucket)
+		}
+	}
+	return buckets, nil
+}
+
+// begin records the call, waits for the configured latency and returns any injected failure
+func (f *FakeProvider) begin(ctx context.Context, op Operation) error {
+	f.mu.Lock()
+	f.calls[op]++
+	latency := f.latency
+	err := f.failures[op]
+	f.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(latency):
+		}
+	}
+	return err
+}
+
+// newFakeAutoclass returns the Autoclass configuration stored for autoclass, nil when disabled
+func newFakeAutoclass(autoclass *Autoclass) *Autoclass {
+	if !autoclass.Enabled {
+		return nil
+	}
+	out := *autoclass
+	return &out
+}
+
+// updateRetentionPolicy applies the retention policy changes of update to bucket, refusing
+// the changes GCS refuses for a locked policy
+func updateRetentionPolicy(bucket *Bucket, update BucketUpdate) error {
+	policy := bucket.RetentionPolicy
+	if period := update.RetentionPeriod; period != nil {
+		switch {
+		case policy != nil && policy.IsLocked && *period < policy.Period:
+			return &Error{Reason: ReasonPermissionDenied, Err: fmt.Errorf("%w: locked retention period cannot be reduced", ErrAccessDenied)}
+		case *period == 0:
+			bucket.RetentionPolicy = nil
+		case policy == nil:
+			bucket.RetentionPolicy = &RetentionPolicy{Period: *period, EffectiveTime: time.Now()}
+		default:
+			policy.Period = *period
+			policy.EffectiveTime = time.Now()
+		}
+	}
+	if update.LockRetentionPolicy {
+		if bucket.RetentionPolicy == nil {
+			return &Error{Reason: ReasonInvalidRequest, Err: fmt.Errorf("no retention policy to lock")}
+		}
+		bucket.RetentionPolicy.IsLocked = true
+	}
+	return nil
+}
+
+// copyBucket returns a copy of bucket that does not share its labels map or policies
+func copyBucket(bucket *Bucket) Bucket {
+	out := *bucket
+	if bucket.Labels != nil {
+		out.Labels = make(map[string]string, len(bucket.Labels))
+		for k, v := range bucket.Labels {
+			out.Labels[k] = v
+		}
+	}
+	if bucket.RetentionPolicy != nil {
+		policy := *bucket.RetentionPolicy
+		out.RetentionPolicy = &policy
+	}
+	if bucket.SoftDeletePolicy != nil {
+		policy := *bucket.SoftDeletePolicy
+		out.SoftDeletePolicy = &policy
+	}
+	if bucket.Autoclass != nil {
+		autoclass := *bucket.Autoclass
+		out.Autoclass = &autoclass
+	}
+	return out
+}
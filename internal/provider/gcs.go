@@ -0,0 +1,315 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// GCSClientOptions configures how NewGCSClient connects to Google Cloud Storage.
+type GCSClientOptions struct {
+	// Endpoint overrides the GCS JSON API endpoint, e.g. "http://fake-gcs-server:4443/storage/v1/".
+	Endpoint string
+
+	// NoAuth disables authentication, as expected by emulators such as fake-gcs-server.
+	NoAuth bool
+
+	// Insecure skips TLS certificate verification for the endpoint. The
+	// resulting HTTP client carries no credentials, so Insecure implies NoAuth.
+	Insecure bool
+}
+
+// NewGCSClient creates a GCS client, optionally pointed at a custom endpoint or emulator.
+func NewGCSClient(ctx context.Context, opts GCSClientOptions) (*storage.Client, error) {
+	var clientOpts []option.ClientOption
+	if opts.Endpoint != "" {
+		clientOpts = append(clientOpts, option.WithEndpoint(opts.Endpoint))
+	}
+	if opts.NoAuth || opts.Insecure {
+		clientOpts = append(clientOpts, option.WithoutAuthentication())
+	}
+	if opts.Insecure {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // emulator use only
+		clientOpts = append(clientOpts, option.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+	return storage.NewClient(ctx, clientOpts...)
+}
+
+// GCSProvider implements BucketProvider on top of Google Cloud Storage.
+type GCSProvider struct {
+	client *storage.Client
+}
+
+var _ BucketProvider = &GCSProvider{}
+
+// NewGCSProvider returns a BucketProvider backed by the given GCS client.
+func NewGCSProvider(client *storage.Client) *GCSProvider {
+	return &GCSProvider{client: client}
+}
+
+// Create creates a new bucket in GCS
+func (p *GCSProvider) Create(ctx context.Context, projectID string, bucket *Bucket) error {
+	lifecycle, err := toStorageLifecycle(bucket.Lifecycle)
+	if err != nil {
+		return fmt.Errorf("Bucket(%q).Create: %w", bucket.Name, err)
+	}
+	attrs := &storage.BucketAttrs{
+		Labels:            bucket.Labels,
+		Location:          bucket.Location,
+		StorageClass:      bucket.StorageClass,
+		VersioningEnabled: bucket.VersioningEnabled,
+		Lifecycle:         lifecycle,
+	}
+	if bucket.Autoclass != nil {
+		attrs.Autoclass = toStorageAutoclass(bucket.Autoclass)
+	}
+	if bucket.RetentionPolicy != nil {
+		attrs.RetentionPolicy = &storage.RetentionPolicy{RetentionPeriod: bucket.RetentionPolicy.Period}
+	}
+	if bucket.SoftDeletePolicy != nil {
+		attrs.SoftDeletePolicy = &storage.SoftDeletePolicy{RetentionDuration: bucket.SoftDeletePolicy.RetentionDuration}
+	}
+	if err := p.client.Bucket(bucket.Name).Create(ctx, projectID, attrs); err != nil {
+		return fmt.Errorf("Bucket(%q).Create: %w", bucket.Name, translateError(err))
+	}
+	return nil
+}
+
+// Get fetches the attributes of a GCS bucket
+func (p *GCSProvider) Get(ctx context.Context, name string) (*Bucket, error) {
+	attrs, err := p.client.Bucket(name).Attrs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Bucket(%q).Attrs: %w", name, translateError(err))
+	}
+	return fromBucketAttrs(attrs), nil
+}
+
+// Update applies changes to an existing GCS bucket
+func (p *GCSProvider) Update(ctx context.Context, name string, update BucketUpdate) (*Bucket, error) {
+	var attrsToUpdate storage.BucketAttrsToUpdate
+	for k, v := range update.SetLabels {
+		attrsToUpdate.SetLabel(k, v)
+	}
+	for _, k := range update.DeleteLabels {
+		attrsToUpdate.DeleteLabel(k)
+	}
+	attrsToUpdate.StorageClass = update.StorageClass
+	if update.Autoclass != nil {
+		attrsToUpdate.Autoclass = toStorageAutoclass(update.Autoclass)
+	}
+	if update.VersioningEnabled != nil {
+		attrsToUpdate.VersioningEnabled = *update.VersioningEnabled
+	}
+	if update.Lifecycle != nil {
+		lifecycle, err := toStorageLifecycle(*update.Lifecycle)
+		if err != nil {
+			return nil, fmt.Errorf("Bucket(%q).Update: %w", name, err)
+		}
+		attrsToUpdate.Lifecycle = &lifecycle
+	}
+	if update.RetentionPeriod != nil {
+		attrsToUpdate.RetentionPolicy = &storage.RetentionPolicy{RetentionPeriod: *update.RetentionPeriod}
+	}
+	if update.SoftDeleteRetention != nil {
+		attrsToUpdate.SoftDeletePolicy = &storage.SoftDeletePolicy{RetentionDuration: *update.SoftDeleteRetention}
+	}
+	handle := p.client.Bucket(name)
+	attrs, err := handle.Update(ctx, attrsToUpdate)
+	if err != nil {
+		return nil, fmt.Errorf("Bucket(%q).Update: %w", name, translateError(err))
+	}
+	if update.LockRetentionPolicy {
+		// GCS only locks the policy of the metageneration the caller has seen
+		conds := storage.BucketConditions{MetagenerationMatch: attrs.MetaGeneration}
+		if err := handle.If(conds).LockRetentionPolicy(ctx); err != nil {
+			return nil, fmt.Errorf("Bucket(%q).LockRetentionPolicy: %w", name, translateError(err))
+		}
+		if attrs, err = handle.Attrs(ctx); err != nil {
+			return nil, fmt.Errorf("Bucket(%q).Attrs: %w", name, translateError(err))
+		}
+	}
+	return fromBucketAttrs(attrs), nil
+}
+
+// Delete deletes a GCS bucket
+func (p *GCSProvider) Delete(ctx context.Context, name string) error {
+	if err := p.client.Bucket(name).Delete(ctx); err != nil {
+		// A conflict on delete means the bucket still has objects, not that it exists
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusConflict {
+			return fmt.Errorf("Bucket(%q).Delete: %w: %v", name, ErrBucketNotEmpty, err)
+		}
+		return fmt.Errorf("Bucket(%q).Delete: %w", name, translateError(err))
+	}
+	return nil
+}
+
+// ListObjects lists the first objects of a GCS bucket, including noncurrent versions
+func (p *GCSProvider) ListObjects(ctx context.Context, bucket string, limit int) ([]Object, error) {
+	it := p.client.Bucket(bucket).Objects(ctx, &storage.Query{Versions: true})
+	it.PageInfo().MaxSize = limit
+	var objects []Object
+	for len(objects) < limit {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Bucket(%q).Objects: %w", bucket, translateError(err))
+		}
+		objects = append(objects, Object{Name: attrs.Name, Generation: attrs.Generation})
+	}
+	return objects, nil
+}
+
+// DeleteObject deletes a single object generation from a GCS bucket
+func (p *GCSProvider) DeleteObject(ctx context.Context, bucket string, object Object) error {
+	err := p.client.Bucket(bucket).Object(object.Name).Generation(object.Generation).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("Object(%q, %q).Delete: %w", bucket, object.Name, translateError(err))
+	}
+	return nil
+}
+
+// List lists the GCS buckets in a project that match the name prefix
+func (p *GCSProvider) List(ctx context.Context, projectID, prefix string) ([]*Bucket, error) {
+	it := p.client.Buckets(ctx, projectID)
+	it.Prefix = prefix
+	var buckets []*Bucket
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Buckets(%q).Next: %w", projectID, translateError(err))
+		}
+		buckets = append(buckets, fromBucketAttrs(attrs))
+	}
+	return buckets, nil
+}
+
+// fromBucketAttrs converts GCS bucket attributes to a Bucket
+func fromBucketAttrs(attrs *storage.BucketAttrs) *Bucket {
+	return &Bucket{
+		Name:              attrs.Name,
+		Location:          attrs.Location,
+		Labels:            attrs.Labels,
+		StorageClass:      attrs.StorageClass,
+		Autoclass:         fromStorageAutoclass(attrs.Autoclass),
+		VersioningEnabled: attrs.VersioningEnabled,
+		Lifecycle:         fromStorageLifecycle(attrs.Lifecycle),
+		RetentionPolicy:   fromStorageRetentionPolicy(attrs.RetentionPolicy),
+		SoftDeletePolicy:  fromStorageSoftDeletePolicy(attrs.SoftDeletePolicy),
+	}
+}
+
+// fromStorageRetentionPolicy converts a GCS retention policy to a RetentionPolicy
+func fromStorageRetentionPolicy(policy *storage.RetentionPolicy) *RetentionPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &RetentionPolicy{
+		Period:        policy.RetentionPeriod,
+		EffectiveTime: policy.EffectiveTime,
+		IsLocked:      policy.IsLocked,
+	}
+}
+
+// toStorageAutoclass converts an Autoclass configuration to its GCS representation
+func toStorageAutoclass(autoclass *Autoclass) *storage.Autoclass {
+	return &storage.Autoclass{
+		Enabled:              autoclass.Enabled,
+		TerminalStorageClass: autoclass.TerminalStorageClass,
+	}
+}
+
+// fromStorageAutoclass converts a GCS Autoclass configuration, nil when it is disabled
+func fromStorageAutoclass(autoclass *storage.Autoclass) *Autoclass {
+	if autoclass == nil || !autoclass.Enabled {
+		return nil
+	}
+	return &Autoclass{
+		Enabled:              true,
+		TerminalStorageClass: autoclass.TerminalStorageClass,
+	}
+}
+
+// fromStorageSoftDeletePolicy converts a GCS soft delete policy to a SoftDeletePolicy
+func fromStorageSoftDeletePolicy(policy *storage.SoftDeletePolicy) *SoftDeletePolicy {
+	if policy == nil {
+		return nil
+	}
+	return &SoftDeletePolicy{
+		RetentionDuration: policy.RetentionDuration,
+		EffectiveTime:     policy.EffectiveTime,
+	}
+}
+
+// translateError maps GCS errors to their provider equivalents and classifies them
+func translateError(err error) error {
+	if errors.Is(err, storage.ErrBucketNotExist) {
+		return ErrBucketNotFound
+	}
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	switch {
+	case isRateLimited(apiErr):
+		return &Error{Reason: ReasonRateLimited, Err: err}
+	case apiErr.Code == http.StatusConflict:
+		return &Error{Reason: ReasonConflict, Err: fmt.Errorf("%w: %v", ErrBucketAlreadyExists, err)}
+	case apiErr.Code == http.StatusForbidden:
+		return &Error{Reason: ReasonPermissionDenied, Err: fmt.Errorf("%w: %v", ErrAccessDenied, err)}
+	case apiErr.Code == http.StatusUnauthorized:
+		return &Error{Reason: ReasonPermissionDenied, Err: err}
+	case apiErr.Code == http.StatusNotFound:
+		return &Error{Reason: ReasonNotFound, Err: err}
+	case apiErr.Code == http.StatusPreconditionFailed:
+		return &Error{Reason: ReasonPreconditionFailed, Err: err}
+	case apiErr.Code == http.StatusRequestTimeout || apiErr.Code >= http.StatusInternalServerError:
+		return &Error{Reason: ReasonUnavailable, Err: err}
+	case apiErr.Code >= http.StatusBadRequest:
+		return &Error{Reason: ReasonInvalidRequest, Err: err}
+	}
+	return err
+}
+
+// isRateLimited reports whether GCS throttled the request, which it signals with a 429
+// or, for some quotas, a 403 with a rate limit reason
+func isRateLimited(apiErr *googleapi.Error) bool {
+	if apiErr.Code == http.StatusTooManyRequests {
+		return true
+	}
+	for _, item := range apiErr.Errors {
+		if item.Reason == "rateLimitExceeded" || item.Reason == "userRateLimitExceeded" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+// lifecycleDateLayout is the format of CreatedBefore
+const lifecycleDateLayout = "2006-01-02"
+
+// toStorageLifecycle converts lifecycle rules to their GCS representation
+func toStorageLifecycle(lifecycle Lifecycle) (storage.Lifecycle, error) {
+	var out storage.Lifecycle
+	for i, rule := range lifecycle.Rules {
+		condition := storage.LifecycleCondition{
+			NumNewerVersions:        rule.Condition.NumNewerVersions,
+			MatchesPrefix:           rule.Condition.MatchesPrefix,
+			MatchesSuffix:           rule.Condition.MatchesSuffix,
+			MatchesStorageClasses:   rule.Condition.MatchesStorageClass,
+			DaysSinceNoncurrentTime: rule.Condition.DaysSinceNoncurrentTime,
+		}
+		if age := rule.Condition.AgeInDays; age != nil {
+			// The client omits an age of 0 unless every object is selected explicitly
+			condition.AgeInDays = *age
+			condition.AllObjects = *age == 0
+		}
+		if rule.Condition.CreatedBefore != "" {
+			createdBefore, err := time.Parse(lifecycleDateLayout, rule.Condition.CreatedBefore)
+			if err != nil {
+				return storage.Lifecycle{}, &Error{
+					Reason: ReasonInvalidRequest,
+					Err:    fmt.Errorf("lifecycle rule %d: invalid createdBefore: %w", i, err),
+				}
+			}
+			condition.CreatedBefore = createdBefore
+		}
+		if isLive := rule.Condition.IsLive; isLive != nil {
+			condition.Liveness = storage.Archived
+			if *isLive {
+				condition.Liveness = storage.Live
+			}
+		}
+		out.Rules = append(out.Rules, storage.LifecycleRule{
+			Action:    storage.LifecycleAction{Type: rule.Action, StorageClass: rule.StorageClass},
+			Condition: condition,
+		})
+	}
+	return out, nil
+}
+
+// fromStorageLifecycle converts GCS lifecycle rules to Lifecycle
+func fromStorageLifecycle(lifecycle storage.Lifecycle) Lifecycle {
+	var out Lifecycle
+	for _, rule := range lifecycle.Rules {
+		condition := LifecycleCondition{
+			NumNewerVersions:        rule.Condition.NumNewerVersions,
+			MatchesPrefix:           rule.Condition.MatchesPrefix,
+			MatchesSuffix:           rule.Condition.MatchesSuffix,
+			MatchesStorageClass:     rule.Condition.MatchesStorageClasses,
+			DaysSinceNoncurrentTime: rule.Condition.DaysSinceNoncurrentTime,
+		}
+		if rule.Condition.AllObjects || rule.Condition.AgeInDays > 0 {
+			age := rule.Condition.AgeInDays
+			condition.AgeInDays = &age
+		}
+		if !rule.Condition.CreatedBefore.IsZero() {
+			condition.CreatedBefore = rule.Condition.CreatedBefore.Format(lifecycleDateLayout)
+		}
+		switch rule.Condition.Liveness {
+		case storage.Live, storage.Archived:
+			isLive := rule.Condition.Liveness == storage.Live
+			condition.IsLive = &isLive
+		}
+		out.Rules = append(out.Rules, LifecycleRule{
+			Action:       rule.Action.Type,
+			StorageClass: rule.Action.StorageClass,
+			Condition:    condition,
+		})
+	}
+	return out
+}
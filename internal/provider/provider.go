@@ -0,0 +1,322 @@
+/*
+Copyright 2025 Ciprian Andrei.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package provider abstracts the storage backend the CloudBucket controller
+// manages buckets in.
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrBucketNotFound is returned when the requested bucket does not exist.
+	ErrBucketNotFound = errors.New("bucket not found")
+
+	// ErrBucketAlreadyExists is returned when creating a bucket whose name is already in use.
+	ErrBucketAlreadyExists = errors.New("bucket already exists")
+
+	// ErrAccessDenied is returned when the caller may not access the bucket, which is
+	// also how a bucket owned by another project presents itself.
+	ErrAccessDenied = errors.New("access denied")
+
+	// ErrBucketNotEmpty is returned when deleting a bucket that still contains objects.
+	ErrBucketNotEmpty = errors.New("bucket not empty")
+)
+
+// ErrorReason classifies a provider error by its cause.
+type ErrorReason string
+
+// Error reasons. Permission, validation, conflict and not-found errors are permanent
+// until something outside of the controller changes; the others may succeed when retried.
+const (
+	// ReasonPermissionDenied means the credentials may not perform the operation.
+	ReasonPermissionDenied ErrorReason = "PermissionDenied"
+
+	// ReasonInvalidRequest means the request was rejected, e.g. for an invalid location or project.
+	ReasonInvalidRequest ErrorReason = "InvalidRequest"
+
+	// ReasonConflict means the operation conflicts with the state of the bucket, e.g. deleting
+	// a bucket that still contains objects.
+	ReasonConflict ErrorReason = "Conflict"
+
+	// ReasonNotFound means the bucket, or the project it is created in, does not exist.
+	ReasonNotFound ErrorReason = "NotFound"
+
+	// ReasonPreconditionFailed means the bucket changed since it was read, e.g. its
+	// metageneration, so the request may succeed against the latest state.
+	ReasonPreconditionFailed ErrorReason = "PreconditionFailed"
+
+	// ReasonRateLimited means the backend throttled the request.
+	ReasonRateLimited ErrorReason = "RateLimited"
+
+	// ReasonUnavailable means the backend failed or could not be reached in time.
+	ReasonUnavailable ErrorReason = "Unavailable"
+
+	// ReasonUnknown is used for errors that could not be classified, which are retried.
+	ReasonUnknown ErrorReason = "Unknown"
+)
+
+// IsTransient reports whether an error with this reason may succeed when retried.
+func (r ErrorReason) IsTransient() bool {
+	switch r {
+	case ReasonPreconditionFailed, ReasonRateLimited, ReasonUnavailable, ReasonUnknown:
+		return true
+	}
+	return false
+}
+
+// Error is a provider error classified by its cause.
+type Error struct {
+	// Reason classifies the error.
+	Reason ErrorReason
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ReasonForError returns the reason of a provider error, or ReasonUnknown if it was not classified.
+func ReasonForError(err error) ErrorReason {
+	var providerErr *Error
+	switch {
+	case errors.As(err, &providerErr):
+		return providerErr.Reason
+	case IsAccessDenied(err):
+		return ReasonPermissionDenied
+	case IsAlreadyExists(err), IsNotEmpty(err):
+		return ReasonConflict
+	case IsNotFound(err):
+		return ReasonNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return ReasonUnavailable
+	}
+	return ReasonUnknown
+}
+
+// IsTransient reports whether err may succeed when retried.
+func IsTransient(err error) bool {
+	return ReasonForError(err).IsTransient()
+}
+
+// IsNotFound reports whether err indicates that a bucket does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrBucketNotFound)
+}
+
+// IsAlreadyExists reports whether err indicates that a bucket name is already in use.
+func IsAlreadyExists(err error) bool {
+	return errors.Is(err, ErrBucketAlreadyExists)
+}
+
+// IsAccessDenied reports whether err indicates that access to a bucket was denied.
+func IsAccessDenied(err error) bool {
+	return errors.Is(err, ErrAccessDenied)
+}
+
+// IsNotEmpty reports whether err indicates that a bucket still contains objects.
+func IsNotEmpty(err error) bool {
+	return errors.Is(err, ErrBucketNotEmpty)
+}
+
+// Bucket is the backend-neutral view of a storage bucket.
+type Bucket struct {
+	// Name is the globally unique name of the bucket.
+	Name string
+
+	// Location is the region or multi-region the bucket is stored in.
+	Location string
+
+	// Labels are the key-value pairs attached to the bucket.
+	Labels map[string]string
+
+	// StorageClass is the default storage class of new objects, e.g. "STANDARD".
+	StorageClass string
+
+	// Autoclass moves objects between storage classes by access, or is nil if disabled.
+	Autoclass *Autoclass
+
+	// VersioningEnabled reports whether noncurrent object versions are kept.
+	VersioningEnabled bool
+
+	// Lifecycle holds the object lifecycle rules of the bucket.
+	Lifecycle Lifecycle
+
+	// RetentionPolicy is the minimum time objects are retained, or nil if there is none.
+	RetentionPolicy *RetentionPolicy
+
+	// SoftDeletePolicy is how long deleted objects are kept, or nil for the backend default.
+	SoftDeletePolicy *SoftDeletePolicy
+}
+
+// SoftDeletePolicy keeps deleted objects restorable for a while.
+type SoftDeletePolicy struct {
+	// RetentionDuration is how long deleted objects are kept; 0 disables soft delete.
+	RetentionDuration time.Duration
+
+	// EffectiveTime is when the policy took effect.
+	EffectiveTime time.Time
+}
+
+// Autoclass moves objects to colder storage classes when they are not accessed, and back
+// to STANDARD when they are.
+type Autoclass struct {
+	// Enabled reports whether Autoclass manages the storage class of objects.
+	Enabled bool
+
+	// TerminalStorageClass is the coldest class objects move to, "NEARLINE" or "ARCHIVE".
+	TerminalStorageClass string
+}
+
+// RetentionPolicy prevents objects from being deleted or replaced until they are old enough.
+type RetentionPolicy struct {
+	// Period is how long objects are retained after they are created.
+	Period time.Duration
+
+	// EffectiveTime is when the policy, or its last period increase, took effect.
+	EffectiveTime time.Time
+
+	// IsLocked reports whether the policy is locked, after which it cannot be removed
+	// and its period can only be increased.
+	IsLocked bool
+}
+
+// Lifecycle is the set of object lifecycle rules of a bucket.
+type Lifecycle struct {
+	// Rules are applied to the objects matching their condition.
+	Rules []LifecycleRule
+}
+
+// LifecycleRule applies an action to the objects matching a condition.
+type LifecycleRule struct {
+	// Action is "Delete", "SetStorageClass" or "AbortIncompleteMultipartUpload".
+	Action string
+
+	// StorageClass is the storage class objects are moved to by SetStorageClass.
+	StorageClass string
+
+	// Condition selects the objects the action is taken on.
+	Condition LifecycleCondition
+}
+
+// LifecycleCondition selects objects by every field that is set.
+type LifecycleCondition struct {
+	// AgeInDays matches objects at least this many days old; 0 matches every object.
+	AgeInDays *int64
+
+	// CreatedBefore matches objects created before this date, formatted as YYYY-MM-DD.
+	CreatedBefore string
+
+	// NumNewerVersions matches noncurrent versions with at least this many newer versions.
+	NumNewerVersions int64
+
+	// IsLive matches live objects when true and noncurrent versions when false.
+	IsLive *bool
+
+	// MatchesPrefix matches objects whose name starts with one of the prefixes.
+	MatchesPrefix []string
+
+	// MatchesSuffix matches objects whose name ends with one of the suffixes.
+	MatchesSuffix []string
+
+	// MatchesStorageClass matches objects in one of the storage classes.
+	MatchesStorageClass []string
+
+	// DaysSinceNoncurrentTime matches versions noncurrent for at least this many days.
+	DaysSinceNoncurrentTime int64
+}
+
+// Object identifies a single version of an object stored in a bucket.
+type Object struct {
+	// Name is the name of the object.
+	Name string
+
+	// Generation identifies the version of the object.
+	Generation int64
+}
+
+// BucketUpdate describes the changes to apply to an existing bucket.
+// Zero-valued fields are left untouched.
+type BucketUpdate struct {
+	// SetLabels are the labels to add or overwrite.
+	SetLabels map[string]string
+
+	// DeleteLabels are the label keys to remove.
+	DeleteLabels []string
+
+	// StorageClass sets the default storage class of new objects.
+	StorageClass string
+
+	// Autoclass enables or disables Autoclass and sets its terminal storage class.
+	Autoclass *Autoclass
+
+	// VersioningEnabled enables or disables object versioning.
+	VersioningEnabled *bool
+
+	// Lifecycle replaces the lifecycle rules; an empty Lifecycle removes them all.
+	Lifecycle *Lifecycle
+
+	// RetentionPeriod sets the retention period; 0 removes the retention policy.
+	RetentionPeriod *time.Duration
+
+	// SoftDeleteRetention sets how long deleted objects are kept; 0 disables soft delete.
+	SoftDeleteRetention *time.Duration
+
+	// LockRetentionPolicy locks the retention policy, after the other changes are applied.
+	// Locking is irreversible.
+	LockRetentionPolicy bool
+}
+
+// IsZero reports whether the update does not change anything.
+func (u BucketUpdate) IsZero() bool {
+	return len(u.SetLabels) == 0 && len(u.DeleteLabels) == 0 && u.StorageClass == "" && u.Autoclass == nil &&
+		u.VersioningEnabled == nil && u.Lifecycle == nil &&
+		u.RetentionPeriod == nil && u.SoftDeleteRetention == nil && !u.LockRetentionPolicy
+}
+
+// BucketProvider manages buckets in a storage backend.
+type BucketProvider interface {
+	// Create creates the bucket in the given project.
+	Create(ctx context.Context, projectID string, bucket *Bucket) error
+
+	// Get returns the current state of the bucket, or ErrBucketNotFound.
+	Get(ctx context.Context, name string) (*Bucket, error)
+
+	// Update applies the changes to the bucket and returns its new state.
+	Update(ctx context.Context, name string, update BucketUpdate) (*Bucket, error)
+
+	// Delete deletes the bucket, or returns ErrBucketNotEmpty if it still contains objects.
+	Delete(ctx context.Context, name string) error
+
+	// ListObjects returns up to limit objects of the bucket, including noncurrent versions.
+	ListObjects(ctx context.Context, bucket string, limit int) ([]Object, error)
+
+	// DeleteObject deletes a single version of an object. Deleting an object that no
+	// longer exists succeeds.
+	DeleteObject(ctx context.Context, bucket string, object Object) error
+
+	// List returns the buckets in the given project whose names start with prefix.
+	List(ctx context.Context, projectID, prefix string) ([]*Bucket, error)
+}
@@ -19,6 +19,7 @@ package e2e
 import (
 	"fmt"
 	"os/exec"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -29,6 +30,36 @@ import (
 
 const namespace = "cloud-storage-controller-system"
 
+// emulatorCloudBucket is the CloudBucket exercised against fake-gcs-server.
+const emulatorCloudBucket = `
+apiVersion: mygroup.example.com/v1
+kind: CloudBucket
+metadata:
+  name: e2e-emulator-bucket
+  namespace: default
+spec:
+  projectID: e2e-project
+  deletePolicy: Delete
+  location: EU
+  labels:
+    env: e2e
+`
+
+// emulatorGet fetches a path from the fake-gcs-server JSON API through the API server service proxy.
+func emulatorGet(path string) ([]byte, error) {
+	cmd := exec.Command("kubectl", "get", "--raw",
+		fmt.Sprintf("/api/v1/namespaces/%s/services/fake-gcs-server:http/proxy%s", namespace, path))
+	return utils.Run(cmd)
+}
+
+// cloudBucketField returns a jsonpath field of the emulator CloudBucket.
+func cloudBucketField(jsonpath string) (string, error) {
+	cmd := exec.Command("kubectl", "get", "cloudbucket", "e2e-emulator-bucket",
+		"-n", "default", "-o", fmt.Sprintf("jsonpath=%s", jsonpath))
+	out, err := utils.Run(cmd)
+	return string(out), err
+}
+
 var _ = Describe("controller", Ordered, func() {
 	BeforeAll(func() {
 		By("installing prometheus operator")
@@ -75,8 +106,8 @@ var _ = Describe("controller", Ordered, func() {
 			cmd = exec.Command("make", "install")
 			_, err = utils.Run(cmd)
 
-			By("deploying the controller-manager")
-			cmd = exec.Command("make", "deploy", fmt.Sprintf("IMG=%s", projectimage))
+			By("deploying the controller-manager against the GCS emulator")
+			cmd = exec.Command("make", "deploy-emulator", fmt.Sprintf("IMG=%s", projectimage))
 			_, err = utils.Run(cmd)
 			ExpectWithOffset(1, err).NotTo(HaveOccurred())
 
@@ -117,5 +148,53 @@ var _ = Describe("controller", Ordered, func() {
 			EventuallyWithOffset(1, verifyControllerUp, time.Minute, time.Second).Should(Succeed())
 
 		})
+
+		It("should create, relabel and delete a bucket in the GCS emulator", func() {
+			By("waiting for fake-gcs-server to be available")
+			cmd := exec.Command("kubectl", "wait", "deployment/fake-gcs-server",
+				"--for", "condition=Available", "-n", namespace, "--timeout", "2m")
+			_, err := utils.Run(cmd)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+
+			By("creating a CloudBucket")
+			cmd = exec.Command("kubectl", "apply", "-f", "-")
+			cmd.Stdin = strings.NewReader(emulatorCloudBucket)
+			_, err = utils.Run(cmd)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+
+			EventuallyWithOffset(1, func() (string, error) {
+				return cloudBucketField("{.status.bucketExists}")
+			}, 2*time.Minute, time.Second).Should(Equal("true"))
+			bucketName, err := cloudBucketField("{.status.bucketName}")
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			ExpectWithOffset(1, bucketName).NotTo(BeEmpty())
+
+			By("validating that the bucket exists in the emulator")
+			bucket, err := emulatorGet("/storage/v1/b/" + bucketName)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			ExpectWithOffset(1, string(bucket)).To(ContainSubstring(`"env":"e2e"`))
+
+			By("relabeling the CloudBucket")
+			cmd = exec.Command("kubectl", "patch", "cloudbucket", "e2e-emulator-bucket", "-n", "default",
+				"--type", "merge", "-p", `{"spec":{"labels":{"env":"relabeled"}}}`)
+			_, err = utils.Run(cmd)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+
+			EventuallyWithOffset(1, func() (string, error) {
+				bucket, err := emulatorGet("/storage/v1/b/" + bucketName)
+				return string(bucket), err
+			}, time.Minute, time.Second).Should(ContainSubstring(`"env":"relabeled"`))
+
+			By("deleting the CloudBucket")
+			cmd = exec.Command("kubectl", "delete", "cloudbucket", "e2e-emulator-bucket",
+				"-n", "default", "--timeout", "2m")
+			_, err = utils.Run(cmd)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+
+			EventuallyWithOffset(1, func() error {
+				_, err := emulatorGet("/storage/v1/b/" + bucketName)
+				return err
+			}, time.Minute, time.Second).Should(HaveOccurred())
+		})
 	})
 })